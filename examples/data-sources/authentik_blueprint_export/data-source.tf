@@ -0,0 +1,13 @@
+# Export a flow and an application as a blueprint
+
+data "authentik_blueprint_export" "backup" {
+  name         = "Backup"
+  flows        = ["default-authentication-flow"]
+  applications = ["grafana"]
+  providers    = [authentik_provider_oauth2.grafana.id]
+}
+
+resource "local_file" "backup" {
+  filename = "${path.module}/backup.yaml"
+  content  = data.authentik_blueprint_export.backup.content
+}
@@ -7,4 +7,10 @@ provider "authentik" {
   # headers {
   #   X-my-header = "foo"
   # }
+  # Optionally authenticate with short-lived tokens issued by an
+  # OAuth2 provider instead of a static token
+  # auth {
+  #   client_id = "terraform"
+  #   jwt_file  = "/var/run/secrets/tokens/authentik"
+  # }
 }
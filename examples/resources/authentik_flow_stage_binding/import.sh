@@ -0,0 +1,2 @@
+# Import by the ID of the binding or by the slug of the flow, name of the stage and order
+terraform import authentik_flow_stage_binding.dummy default-authentication-flow/default-authentication-identification/10
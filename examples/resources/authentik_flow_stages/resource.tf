@@ -0,0 +1,34 @@
+# Bind stages to a flow in the order they are listed
+
+resource "authentik_stage_identification" "name" {
+  name           = "test-identification"
+  user_fields    = ["username"]
+  password_stage = authentik_stage_password.name.id
+}
+
+resource "authentik_stage_password" "name" {
+  name     = "test-password"
+  backends = ["authentik.core.auth.InbuiltBackend"]
+}
+
+resource "authentik_stage_user_login" "name" {
+  name = "test-login"
+}
+
+resource "authentik_flow" "flow" {
+  name        = "test-flow"
+  title       = "Test flow"
+  slug        = "test-flow"
+  designation = "authentication"
+}
+
+resource "authentik_flow_stages" "flow" {
+  flow = authentik_flow.flow.slug
+  binding {
+    stage = authentik_stage_identification.name.id
+  }
+  binding {
+    stage                = authentik_stage_user_login.name.id
+    re_evaluate_policies = true
+  }
+}
@@ -0,0 +1,4 @@
+# Importing by the group's UUID manages all of the group's current members
+terraform import authentik_group_members.admins 1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71
+# Import only some of the group's members by their IDs
+terraform import authentik_group_members.admins 1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71:4,7
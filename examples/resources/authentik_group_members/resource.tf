@@ -0,0 +1,15 @@
+# Add users to an existing group, without managing the group's other members
+
+data "authentik_group" "admins" {
+  name = "authentik Admins"
+}
+
+resource "authentik_user" "name" {
+  username = "user"
+  name     = "User"
+}
+
+resource "authentik_group_members" "admins" {
+  group = data.authentik_group.admins.id
+  users = [authentik_user.name.id]
+}
@@ -0,0 +1,2 @@
+# Group memberships can be imported by `group-uuid:user-id`
+terraform import authentik_group_membership.membership 1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71:42
@@ -0,0 +1,15 @@
+# Add a user to a group, without managing the group's other members
+
+resource "authentik_group" "group" {
+  name = "group-name"
+}
+
+resource "authentik_user" "name" {
+  username = "user"
+  name     = "User"
+}
+
+resource "authentik_group_membership" "membership" {
+  group = authentik_group.group.id
+  user  = authentik_user.name.id
+}
@@ -0,0 +1,3 @@
+# Import by the model and either the identifier or the managed key of the object
+terraform import authentik_managed_object.default-authentication-flow flow:identifier=default-authentication-flow
+terraform import authentik_managed_object.scope-email property_mapping:managed=goauthentik.io/providers/oauth2/scope-email
@@ -0,0 +1,30 @@
+# Customize the default authentication flow without taking over the whole flow
+
+resource "authentik_managed_object" "default-authentication-flow" {
+  model      = "flow"
+  identifier = "default-authentication-flow"
+  fields = jsonencode({
+    title = "Welcome to Company"
+  })
+  on_destroy = "restore"
+}
+
+# Change the default brand
+
+resource "authentik_managed_object" "default-brand" {
+  model      = "brand"
+  identifier = "authentik-default"
+  fields = jsonencode({
+    branding_title = "Company"
+  })
+}
+
+# Property mappings and sources can also be looked up by their managed key
+
+resource "authentik_managed_object" "scope-email" {
+  model   = "property_mapping"
+  managed = "goauthentik.io/providers/oauth2/scope-email"
+  fields = jsonencode({
+    description = "Email address"
+  })
+}
@@ -0,0 +1,2 @@
+# Import by the ID of the binding or by the UUID of the target and name of the policy
+terraform import authentik_policy_binding.app-access 1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71/my-policy
@@ -0,0 +1,25 @@
+# Manage all bindings of an application, evaluated in the listed order
+
+resource "authentik_policy_expression" "policy" {
+  name       = "example"
+  expression = "return True"
+}
+
+data "authentik_group" "admins" {
+  name = "authentik Admins"
+}
+
+resource "authentik_application" "name" {
+  name = "test app"
+  slug = "test-app"
+}
+
+resource "authentik_policy_bindings" "app-access" {
+  target = authentik_application.name.uuid
+  binding {
+    group = data.authentik_group.admins.id
+  }
+  binding {
+    policy = authentik_policy_expression.policy.id
+  }
+}
@@ -0,0 +1,5 @@
+# Import a global permission by role UUID and permission
+terraform import authentik_rbac_permission_role.global-permission 0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11/authentik_flows.inspect_flow
+
+# Import an object permission by role UUID, permission, model and object ID
+terraform import authentik_rbac_permission_role.object-permission 0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11/authentik_flows.view_flow/authentik_flows.flow/9a1c7d3e-2b4f-4c8a-b6e5-0d2f1a3c4b5e
@@ -0,0 +1,5 @@
+# Import a role assigned to a group by role UUID and group UUID
+terraform import authentik_rbac_role_assignment.group 0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11/group/1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71
+
+# Import a role assigned to a user by role UUID and user ID
+terraform import authentik_rbac_role_assignment.user 0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11/user/42
@@ -0,0 +1,26 @@
+# Assign a role to an existing group
+
+resource "authentik_rbac_role" "role" {
+  name = "role-name"
+}
+
+data "authentik_group" "group" {
+  name = "group-name"
+}
+
+resource "authentik_rbac_role_assignment" "group" {
+  role  = authentik_rbac_role.role.id
+  group = data.authentik_group.group.id
+}
+
+# Assign a role to a user
+
+resource "authentik_user" "name" {
+  username = "user"
+  name     = "User"
+}
+
+resource "authentik_rbac_role_assignment" "user" {
+  role = authentik_rbac_role.role.id
+  user = authentik_user.name.id
+}
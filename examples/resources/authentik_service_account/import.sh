@@ -0,0 +1,2 @@
+# Import by the ID of the service account user, the token and a group with the same name are imported with it
+terraform import authentik_service_account.sa 42
@@ -0,0 +1,12 @@
+# Create a service account with a group and a token that expires
+
+resource "authentik_service_account" "ci" {
+  name         = "ci-pipeline"
+  create_group = true
+  expires      = "2027-01-01T00:00:00Z"
+}
+
+output "ci_token" {
+  value     = authentik_service_account.ci.token
+  sensitive = true
+}
@@ -28,3 +28,13 @@ resource "authentik_user" "name" {
   name     = "User"
   roles    = [authentik_rbac_role.role.id]
 }
+
+# Create a user with a password that isn't stored in the state,
+# increment password_version to rotate it
+
+resource "authentik_user" "name" {
+  username         = "user"
+  name             = "User"
+  password_wo      = var.user_password
+  password_version = 1
+}
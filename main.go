@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
+	"goauthentik.io/terraform-provider-authentik/pkg/blueprint"
 	"goauthentik.io/terraform-provider-authentik/pkg/provider"
 )
 
@@ -19,6 +23,15 @@ var version string = "dev"
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(export(os.Args[2:]))
+		case "convert-blueprint":
+			os.Exit(convertBlueprint(os.Args[2:]))
+		}
+	}
+
 	var debugMode bool
 	var versionMode bool
 
@@ -40,3 +53,70 @@ func main() {
 
 	plugin.Serve(opts)
 }
+
+// export Write the objects of an authentik instance as Terraform configuration.
+// The connection is configured with the same environment variables as the provider.
+func export(args []string) int {
+	var outputDir string
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	fs.StringVar(&outputDir, "output", ".", "Directory to write the generated configuration to")
+	_ = fs.Parse(args)
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	diags := provider.Export(context.Background(), provider.Provider(version, false), outputDir)
+	for _, d := range diags {
+		severity := "Warning"
+		if d.Severity == diag.Error {
+			severity = "Error"
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s\n", severity, d.Summary)
+	}
+	if diags.HasError() {
+		return 1
+	}
+	return 0
+}
+
+// convertBlueprint Convert an authentik blueprint into Terraform configuration
+func convertBlueprint(args []string) int {
+	var outputFile string
+
+	fs := flag.NewFlagSet("convert-blueprint", flag.ExitOnError)
+	fs.StringVar(&outputFile, "output", "", "File to write the generated configuration to, defaults to stdout")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s convert-blueprint [-output file.tf] blueprint.yaml\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	res, err := blueprint.Convert(content, provider.Provider(version, false).ResourcesMap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, w := range res.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if outputFile == "" {
+		_, _ = os.Stdout.Write(res.HCL)
+		return 0
+	}
+	if err := os.WriteFile(outputFile, res.HCL, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,445 @@
+// Package blueprint converts authentik blueprints into Terraform configuration for this provider.
+package blueprint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// Models whose resource type can't be derived from their app label
+var modelOverrides = map[string]string{
+	"authentik_core.application":                                          "authentik_application",
+	"authentik_core.group":                                                "authentik_group",
+	"authentik_core.user":                                                 "authentik_user",
+	"authentik_core.token":                                                "authentik_token",
+	"authentik_brands.brand":                                              "authentik_brand",
+	"authentik_crypto.certificatekeypair":                                 "authentik_certificate_key_pair",
+	"authentik_flows.flow":                                                "authentik_flow",
+	"authentik_flows.flowstagebinding":                                    "authentik_flow_stage_binding",
+	"authentik_policies.policybinding":                                    "authentik_policy_binding",
+	"authentik_outposts.outpost":                                          "authentik_outpost",
+	"authentik_events.notificationrule":                                   "authentik_event_rule",
+	"authentik_events.notificationtransport":                              "authentik_event_transport",
+	"authentik_rbac.role":                                                 "authentik_rbac_role",
+	"authentik_stages_prompt.prompt":                                      "authentik_stage_prompt_field",
+	"authentik_stages_mtls.mutualtlsstage":                                "authentik_stage_mutual_tls",
+	"authentik_providers_rac.endpoint":                                    "authentik_rac_endpoint",
+	"authentik_providers_oauth2.scopemapping":                             "authentik_property_mapping_provider_scope",
+	"authentik_providers_saml.samlpropertymapping":                        "authentik_property_mapping_provider_saml",
+	"authentik_providers_scim.scimmapping":                                "authentik_property_mapping_provider_scim",
+	"authentik_providers_rac.racpropertymapping":                          "authentik_property_mapping_provider_rac",
+	"authentik_providers_radius.radiusproviderpropertymapping":            "authentik_property_mapping_provider_radius",
+	"authentik_providers_google_workspace.googleworkspaceprovidermapping": "authentik_property_mapping_provider_google_workspace",
+	"authentik_providers_microsoft_entra.microsoftentraprovidermapping":   "authentik_property_mapping_provider_microsoft_entra",
+}
+
+// modelPrefix Models of an app label prefix, which are mapped to resource types by their app label
+type modelPrefix struct {
+	typePrefix string
+	// Suffix of the model name, to exclude other models of the same app
+	modelSuffix string
+}
+
+var modelPrefixes = map[string]modelPrefix{
+	"authentik_stages_":    {"authentik_stage_", "stage"},
+	"authentik_policies_":  {"authentik_policy_", "policy"},
+	"authentik_providers_": {"authentik_provider_", "provider"},
+	"authentik_sources_":   {"authentik_source_", "source"},
+}
+
+// Attributes of a model which are called differently in the resource
+var attributeRenames = map[string]map[string]string{
+	"authentik_application": {
+		"provider": "protocol_provider",
+	},
+}
+
+// dataSource A data source used for `!Find` tags that don't match an entry of the blueprint
+type dataSource struct {
+	dataType string
+	// Fields of the model which can be used to look up the object
+	fields []string
+}
+
+var dataSources = map[string]dataSource{
+	"authentik_flows.flow":                {"authentik_flow", []string{"slug"}},
+	"authentik_core.group":                {"authentik_group", []string{"name"}},
+	"authentik_core.user":                 {"authentik_user", []string{"username"}},
+	"authentik_crypto.certificatekeypair": {"authentik_certificate_key_pair", []string{"name"}},
+}
+
+var invalidName = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Result The converted blueprint
+type Result struct {
+	HCL []byte
+	// Problems found while converting, like unsupported models or tags.
+	// Values which could not be converted are set to null.
+	Warnings []string
+}
+
+type entry struct {
+	model        string
+	id           string
+	resourceType string
+	name         string
+	// Identifiers and attributes of the entry, in the order of the blueprint
+	keys   []string
+	fields map[string]*yaml.Node
+}
+
+type converter struct {
+	resources map[string]*schema.Resource
+	entries   []*entry
+	byID      map[string]*entry
+	names     map[string]map[string]bool
+	data      map[string]hcl.Traversal
+	body      *hclwrite.Body
+	dataBody  *hclwrite.Body
+	warnings  []string
+}
+
+// ResourceType Get the resource type matching a blueprint model, or an empty string if the
+// model is not supported by the provider
+func ResourceType(model string, resources map[string]*schema.Resource) string {
+	resourceType, ok := modelOverrides[model]
+	if !ok {
+		app, modelName, _ := strings.Cut(model, ".")
+		for prefix, mp := range modelPrefixes {
+			after, ok := strings.CutPrefix(app, prefix)
+			if !ok {
+				continue
+			}
+			if strings.HasSuffix(modelName, mp.modelSuffix) {
+				resourceType = mp.typePrefix + after
+			} else if prefix == "authentik_sources_" && strings.HasSuffix(modelName, "propertymapping") {
+				resourceType = "authentik_property_mapping_source_" + after
+			}
+		}
+	}
+	if _, ok := resources[resourceType]; !ok {
+		return ""
+	}
+	return resourceType
+}
+
+// Convert Convert the entries of a blueprint into resources of this provider, using the
+// schema of `resources` to map attributes
+func Convert(content []byte, resources map[string]*schema.Resource) (*Result, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) < 1 {
+		return nil, errors.New("blueprint is empty")
+	}
+	entries := mappingValue(doc.Content[0], "entries")
+	if entries == nil || entries.Kind != yaml.SequenceNode {
+		return nil, errors.New("blueprint does not contain any entries")
+	}
+
+	f := hclwrite.NewEmptyFile()
+	c := &converter{
+		resources: resources,
+		byID:      map[string]*entry{},
+		names:     map[string]map[string]bool{},
+		data:      map[string]hcl.Traversal{},
+		body:      hclwrite.NewEmptyFile().Body(),
+		dataBody:  f.Body(),
+	}
+	for i, node := range entries.Content {
+		c.addEntry(i, node)
+	}
+	for _, e := range c.entries {
+		c.writeEntry(e)
+	}
+	if len(c.dataBody.Blocks()) > 0 {
+		c.dataBody.AppendNewline()
+	}
+	f.Body().AppendUnstructuredTokens(c.body.BuildTokens(nil))
+	return &Result{
+		HCL:      hclwrite.Format(f.Bytes()),
+		Warnings: c.warnings,
+	}, nil
+}
+
+func (c *converter) warn(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// uniqueName Convert `name` into a valid resource name, unique for the resource type
+func (c *converter) uniqueName(resourceType string, name string) string {
+	if c.names[resourceType] == nil {
+		c.names[resourceType] = map[string]bool{}
+	}
+	base := strings.Trim(invalidName.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if base == "" {
+		base = "entry"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+	name = base
+	for i := 2; c.names[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	c.names[resourceType][name] = true
+	return name
+}
+
+func (c *converter) addEntry(idx int, node *yaml.Node) {
+	e := &entry{fields: map[string]*yaml.Node{}}
+	if model := mappingValue(node, "model"); model != nil {
+		e.model = model.Value
+	}
+	if id := mappingValue(node, "id"); id != nil {
+		e.id = id.Value
+	}
+	if state := mappingValue(node, "state"); state != nil && state.Value == "absent" {
+		c.warn("Entry %d (%s): entries with state 'absent' are not supported, skipping", idx, e.model)
+		return
+	}
+	if conditions := mappingValue(node, "conditions"); conditions != nil {
+		c.warn("Entry %d (%s): conditions are not supported, the entry is always created", idx, e.model)
+	}
+	for _, section := range []string{"identifiers", "attrs"} {
+		values := mappingValue(node, section)
+		if values == nil || values.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(values.Content); i += 2 {
+			key := values.Content[i].Value
+			if key == "pk" {
+				continue
+			}
+			if _, ok := e.fields[key]; !ok {
+				e.keys = append(e.keys, key)
+			}
+			e.fields[key] = values.Content[i+1]
+		}
+	}
+	e.resourceType = ResourceType(e.model, c.resources)
+	if e.resourceType == "" {
+		c.warn("Entry %d: model '%s' is not supported, skipping", idx, e.model)
+		return
+	}
+	name := e.id
+	for _, field := range []string{"slug", "name", "username", "field_key"} {
+		if v, ok := e.fields[field]; name == "" && ok && v.Kind == yaml.ScalarNode {
+			name = v.Value
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("entry_%d", idx)
+	}
+	e.name = c.uniqueName(e.resourceType, name)
+	c.entries = append(c.entries, e)
+	if e.id != "" {
+		c.byID[e.id] = e
+	}
+}
+
+// reference Get a reference to the primary key of the object created for an entry. Flows and
+// applications use their slug as ID, so those are referenced by their UUID.
+func (c *converter) reference(e *entry) hcl.Traversal {
+	attr := "id"
+	if _, ok := c.resources[e.resourceType].Schema["uuid"]; ok {
+		attr = "uuid"
+	}
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: e.resourceType},
+		hcl.TraverseAttr{Name: e.name},
+		hcl.TraverseAttr{Name: attr},
+	}
+}
+
+func (c *converter) writeEntry(e *entry) {
+	if len(c.body.Blocks()) > 0 {
+		c.body.AppendNewline()
+	}
+	block := c.body.AppendNewBlock("resource", []string{e.resourceType, e.name})
+	sm := c.resources[e.resourceType].Schema
+	for _, key := range e.keys {
+		attr := key
+		if renamed, ok := attributeRenames[e.resourceType][key]; ok {
+			attr = renamed
+		}
+		s, ok := sm[attr]
+		if !ok || (!s.Required && !s.Optional) {
+			c.warn("%s.%s: attribute '%s' of model '%s' is not supported, skipping", e.resourceType, e.name, key, e.model)
+			continue
+		}
+		if res, ok := s.Elem.(*schema.Resource); ok && e.fields[key].Kind == yaml.SequenceNode {
+			for _, item := range e.fields[key].Content {
+				c.writeNestedBlock(e, block.Body().AppendNewBlock(attr, nil), res, item)
+			}
+			continue
+		}
+		block.Body().SetAttributeRaw(attr, c.tokens(e, s, e.fields[key]))
+	}
+}
+
+func (c *converter) writeNestedBlock(e *entry, block *hclwrite.Block, res *schema.Resource, node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		c.warn("%s.%s: expected a mapping for block '%s'", e.resourceType, e.name, block.Type())
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		s, ok := res.Schema[key]
+		if !ok {
+			c.warn("%s.%s: attribute '%s.%s' is not supported, skipping", e.resourceType, e.name, block.Type(), key)
+			continue
+		}
+		block.Body().SetAttributeRaw(key, c.tokens(e, s, node.Content[i+1]))
+	}
+}
+
+func (c *converter) null() hclwrite.Tokens {
+	return hclwrite.TokensForIdentifier("null")
+}
+
+// tokens Convert a YAML value into an expression, resolving `!KeyOf` and `!Find` tags
+func (c *converter) tokens(e *entry, s *schema.Schema, node *yaml.Node) hclwrite.Tokens {
+	switch node.Tag {
+	case "!KeyOf":
+		target, ok := c.byID[node.Value]
+		if !ok {
+			c.warn("%s.%s: '!KeyOf %s' does not match any supported entry", e.resourceType, e.name, node.Value)
+			return c.null()
+		}
+		return hclwrite.TokensForTraversal(c.reference(target))
+	case "!Find":
+		return c.find(e, node)
+	}
+	if strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		c.warn("%s.%s: tag '%s' is not supported", e.resourceType, e.name, node.Tag)
+		return c.null()
+	}
+	// Values without a schema, like the contents of JSON attributes, keep their type
+	elem, _ := s.Elem.(*schema.Schema)
+	if elem == nil {
+		elem = &schema.Schema{Type: schema.TypeInvalid}
+	}
+	switch node.Kind {
+	case yaml.AliasNode:
+		return c.tokens(e, s, node.Alias)
+	case yaml.SequenceNode:
+		// Attributes like `attributes` of groups are JSON strings in the provider
+		if s.Type == schema.TypeString {
+			return hclwrite.TokensForFunctionCall("jsonencode", c.tokens(e, &schema.Schema{Type: schema.TypeList}, node))
+		}
+		items := make([]hclwrite.Tokens, len(node.Content))
+		for i, item := range node.Content {
+			items[i] = c.tokens(e, elem, item)
+		}
+		return hclwrite.TokensForTuple(items)
+	case yaml.MappingNode:
+		if s.Type == schema.TypeString {
+			return hclwrite.TokensForFunctionCall("jsonencode", c.tokens(e, &schema.Schema{Type: schema.TypeMap}, node))
+		}
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(node.Content[i].Value)),
+				Value: c.tokens(e, elem, node.Content[i+1]),
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	switch node.Tag {
+	case "!!null":
+		return c.null()
+	case "!!bool":
+		return hclwrite.TokensForValue(cty.BoolVal(node.Value == "true" || node.Value == "True"))
+	case "!!int", "!!float":
+		if s.Type != schema.TypeString {
+			return hclwrite.TokensForIdentifier(node.Value)
+		}
+	}
+	return hclwrite.TokensForValue(cty.StringVal(node.Value))
+}
+
+// find Resolve a `!Find [model, [field, value], ...]` tag to an entry of the blueprint,
+// or to a data source when the object isn't part of the blueprint
+func (c *converter) find(e *entry, node *yaml.Node) hclwrite.Tokens {
+	if node.Kind != yaml.SequenceNode || len(node.Content) < 2 {
+		c.warn("%s.%s: invalid '!Find' tag", e.resourceType, e.name)
+		return c.null()
+	}
+	model := node.Content[0].Value
+	query := map[string]string{}
+	for _, pair := range node.Content[1:] {
+		if pair.Kind != yaml.SequenceNode || len(pair.Content) != 2 || pair.Content[1].Kind != yaml.ScalarNode {
+			c.warn("%s.%s: '!Find' for '%s' with non-static values is not supported", e.resourceType, e.name, model)
+			return c.null()
+		}
+		query[pair.Content[0].Value] = pair.Content[1].Value
+	}
+	for _, candidate := range c.entries {
+		if candidate.model != model {
+			continue
+		}
+		matches := true
+		for field, value := range query {
+			v, ok := candidate.fields[field]
+			if !ok || v.Kind != yaml.ScalarNode || v.Value != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return hclwrite.TokensForTraversal(c.reference(candidate))
+		}
+	}
+	ds, ok := dataSources[model]
+	if !ok || len(query) != 1 {
+		c.warn("%s.%s: '!Find' for '%s' does not match any entry of the blueprint", e.resourceType, e.name, model)
+		return c.null()
+	}
+	for _, field := range ds.fields {
+		value, ok := query[field]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%s", ds.dataType, field, value)
+		if ref, ok := c.data[key]; ok {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		name := c.uniqueName("data."+ds.dataType, value)
+		if len(c.dataBody.Blocks()) > 0 {
+			c.dataBody.AppendNewline()
+		}
+		block := c.dataBody.AppendNewBlock("data", []string{ds.dataType, name})
+		block.Body().SetAttributeValue(field, cty.StringVal(value))
+		c.data[key] = hcl.Traversal{
+			hcl.TraverseRoot{Name: "data"},
+			hcl.TraverseAttr{Name: ds.dataType},
+			hcl.TraverseAttr{Name: name},
+			hcl.TraverseAttr{Name: "id"},
+		}
+		return hclwrite.TokensForTraversal(c.data[key])
+	}
+	c.warn("%s.%s: '!Find' for '%s' by %v is not supported", e.resourceType, e.name, model, query)
+	return c.null()
+}
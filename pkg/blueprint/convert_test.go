@@ -0,0 +1,180 @@
+package blueprint
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testResources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"authentik_flow": {
+			Schema: map[string]*schema.Schema{
+				"uuid":        {Type: schema.TypeString, Computed: true},
+				"name":        {Type: schema.TypeString, Required: true},
+				"slug":        {Type: schema.TypeString, Required: true},
+				"title":       {Type: schema.TypeString, Required: true},
+				"designation": {Type: schema.TypeString, Required: true},
+			},
+		},
+		"authentik_stage_prompt_field": {
+			Schema: map[string]*schema.Schema{
+				"name":      {Type: schema.TypeString, Required: true},
+				"field_key": {Type: schema.TypeString, Required: true},
+				"label":     {Type: schema.TypeString, Required: true},
+				"type":      {Type: schema.TypeString, Required: true},
+				"required":  {Type: schema.TypeBool, Optional: true},
+				"order":     {Type: schema.TypeInt, Optional: true},
+			},
+		},
+		"authentik_stage_prompt": {
+			Schema: map[string]*schema.Schema{
+				"name":   {Type: schema.TypeString, Required: true},
+				"fields": {Type: schema.TypeList, Required: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			},
+		},
+		"authentik_flow_stage_binding": {
+			Schema: map[string]*schema.Schema{
+				"target": {Type: schema.TypeString, Required: true},
+				"stage":  {Type: schema.TypeString, Required: true},
+				"order":  {Type: schema.TypeInt, Required: true},
+			},
+		},
+		"authentik_group": {
+			Schema: map[string]*schema.Schema{
+				"name":       {Type: schema.TypeString, Required: true},
+				"attributes": {Type: schema.TypeString, Optional: true},
+			},
+		},
+		"authentik_policy_binding": {
+			Schema: map[string]*schema.Schema{
+				"target": {Type: schema.TypeString, Required: true},
+				"group":  {Type: schema.TypeString, Optional: true},
+				"order":  {Type: schema.TypeInt, Required: true},
+			},
+		},
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	resources := testResources()
+	assert.Equal(t, "authentik_flow", ResourceType("authentik_flows.flow", resources))
+	assert.Equal(t, "authentik_stage_prompt", ResourceType("authentik_stages_prompt.promptstage", resources))
+	assert.Equal(t, "authentik_stage_prompt_field", ResourceType("authentik_stages_prompt.prompt", resources))
+	assert.Equal(t, "", ResourceType("authentik_stages_invitation.invitation", resources))
+	assert.Equal(t, "", ResourceType("authentik_blueprints.metaapplyblueprint", resources))
+}
+
+func TestConvert(t *testing.T) {
+	res, err := Convert([]byte(`
+version: 1
+metadata:
+  name: test
+entries:
+  - model: authentik_flows.flow
+    id: flow
+    identifiers:
+      slug: enrollment
+    attrs:
+      name: Enrollment
+      title: Welcome!
+      designation: enrollment
+  - model: authentik_stages_prompt.prompt
+    id: prompt-field-username
+    identifiers:
+      name: enrollment-field-username
+    attrs:
+      field_key: username
+      label: Username
+      type: username
+      required: true
+      order: 0
+  - model: authentik_stages_prompt.promptstage
+    id: stage
+    identifiers:
+      name: enrollment-prompt
+    attrs:
+      fields:
+        - !KeyOf prompt-field-username
+  - model: authentik_flows.flowstagebinding
+    identifiers:
+      target: !KeyOf flow
+      stage: !KeyOf stage
+      order: 10
+  - model: authentik_core.group
+    identifiers:
+      name: users
+    attrs:
+      attributes:
+        quota: 10
+  - model: authentik_policies.policybinding
+    identifiers:
+      target: !Find [authentik_flows.flow, [slug, default-authentication-flow]]
+      group: !Find [authentik_core.group, [name, users]]
+      order: 0
+  - model: authentik_blueprints.metaapplyblueprint
+    attrs:
+      identifiers:
+        name: other
+  - model: authentik_core.group
+    identifiers:
+      name: admins
+    attrs:
+      attributes: !Env ADMIN_ATTRIBUTES
+`), testResources())
+	assert.NoError(t, err)
+	assert.Equal(t, `data "authentik_flow" "default-authentication-flow" {
+  slug = "default-authentication-flow"
+}
+
+resource "authentik_flow" "flow" {
+  slug        = "enrollment"
+  name        = "Enrollment"
+  title       = "Welcome!"
+  designation = "enrollment"
+}
+
+resource "authentik_stage_prompt_field" "prompt-field-username" {
+  name      = "enrollment-field-username"
+  field_key = "username"
+  label     = "Username"
+  type      = "username"
+  required  = true
+  order     = 0
+}
+
+resource "authentik_stage_prompt" "stage" {
+  name   = "enrollment-prompt"
+  fields = [authentik_stage_prompt_field.prompt-field-username.id]
+}
+
+resource "authentik_flow_stage_binding" "entry_3" {
+  target = authentik_flow.flow.uuid
+  stage  = authentik_stage_prompt.stage.id
+  order  = 10
+}
+
+resource "authentik_group" "users" {
+  name = "users"
+  attributes = jsonencode({
+    "quota" = 10
+  })
+}
+
+resource "authentik_policy_binding" "entry_5" {
+  target = data.authentik_flow.default-authentication-flow.id
+  group  = authentik_group.users.id
+  order  = 0
+}
+
+resource "authentik_group" "admins" {
+  name       = "admins"
+  attributes = null
+}
+`, string(res.HCL))
+	assert.Equal(t, []string{
+		"Entry 6: model 'authentik_blueprints.metaapplyblueprint' is not supported, skipping",
+		"authentik_group.admins: tag '!Env' is not supported",
+	}, res.Warnings)
+}
@@ -2,14 +2,22 @@ package helpers
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// Keys used by Django REST Framework for errors that don't relate to a single field
+var nonFieldErrorKeys = []string{"non_field_errors", "detail"}
+
 func HTTPToDiag(d *schema.ResourceData, r *http.Response, err error) diag.Diagnostics {
 	if r == nil {
 		return diag.Errorf("HTTP Error '%s' without http response", err.Error())
@@ -24,5 +32,148 @@ func HTTPToDiag(d *schema.ResourceData, r *http.Response, err error) diag.Diagno
 		log.Printf("[DEBUG] authentik: failed to read response: %s", er.Error())
 	}
 	log.Printf("[DEBUG] authentik: error response: %s", buff.String())
+	if r.StatusCode == 400 {
+		if diags := ValidationErrorToDiag(d, buff.Bytes(), r.Request); len(diags) > 0 {
+			return diags
+		}
+	}
 	return diag.Errorf("HTTP Error '%s' during request '%s %s': \"%s\"", err.Error(), r.Request.Method, r.Request.URL.Path, buff.String())
 }
+
+// ValidationErrorToDiag Convert a validation error returned by authentik into diagnostics.
+// Errors for a field are scoped to the attribute of the same name when `d` has such an attribute,
+// other errors are returned without an attribute path. Returns nil when the body isn't a validation error.
+func ValidationErrorToDiag(d *schema.ResourceData, body []byte, req *http.Request) diag.Diagnostics {
+	var raw any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+	request := "unknown request"
+	if req != nil {
+		request = fmt.Sprintf("%s %s", req.Method, req.URL.Path)
+	}
+	diags := diag.Diagnostics{}
+	switch v := raw.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			if slices.Contains(nonFieldErrorKeys, key) {
+				for _, msg := range validationMessages(v[key]) {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  msg,
+						Detail:   fmt.Sprintf("Validation error during request '%s'", request),
+					})
+				}
+				continue
+			}
+			path, addr := errorPathAttr(d, cty.Path{}, "", key)
+			diags = append(diags, fieldErrorToDiag(d, v[key], path, addr, key, request)...)
+		}
+	case []any:
+		for _, msg := range validationMessages(v) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  msg,
+				Detail:   fmt.Sprintf("Validation error during request '%s'", request),
+			})
+		}
+	}
+	if len(diags) < 1 {
+		return nil
+	}
+	return diags
+}
+
+// fieldErrorToDiag Recursively convert the errors of a field, which can either be a list of messages,
+// a list of errors for nested objects or a mapping of nested fields or list indexes. `addr` is the
+// address of the field in `d`, and `path` is nil when the field isn't an attribute of the resource.
+func fieldErrorToDiag(d *schema.ResourceData, raw any, path cty.Path, addr string, field string, request string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	switch v := raw.(type) {
+	case string:
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       v,
+			Detail:        fmt.Sprintf("Invalid value for '%s' during request '%s'", field, request),
+			AttributePath: path,
+		})
+	case []any:
+		for i, item := range v {
+			switch item.(type) {
+			case string:
+				diags = append(diags, fieldErrorToDiag(d, item, path, addr, field, request)...)
+			default:
+				itemPath, itemAddr := errorPathIndex(d, path, addr, i)
+				diags = append(diags, fieldErrorToDiag(d, item, itemPath, itemAddr, field, request)...)
+			}
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			if idx, err := strconv.Atoi(key); err == nil {
+				itemPath, itemAddr := errorPathIndex(d, path, addr, idx)
+				diags = append(diags, fieldErrorToDiag(d, v[key], itemPath, itemAddr, field, request)...)
+			} else {
+				keyPath, keyAddr := errorPathAttr(d, path, addr, key)
+				diags = append(diags, fieldErrorToDiag(d, v[key], keyPath, keyAddr, fmt.Sprintf("%s.%s", field, key), request)...)
+			}
+		}
+	}
+	return diags
+}
+
+// errorPathAttr Extend the path of an error by a nested attribute, nil when the resource doesn't have it
+func errorPathAttr(d *schema.ResourceData, path cty.Path, addr string, key string) (cty.Path, string) {
+	if d == nil || path == nil {
+		return nil, ""
+	}
+	full := key
+	if addr != "" {
+		full = fmt.Sprintf("%s.%s", addr, key)
+	}
+	// Unknown keys don't have a value, not even the zero value
+	if d.Get(full) == nil {
+		return nil, ""
+	}
+	return path.GetAttr(key), full
+}
+
+// errorPathIndex Extend the path of an error by a list index, nil when the attribute isn't a list,
+// as elements of sets and maps can't be addressed by their index
+func errorPathIndex(d *schema.ResourceData, path cty.Path, addr string, idx int) (cty.Path, string) {
+	if d == nil || path == nil {
+		return nil, ""
+	}
+	if _, ok := d.Get(addr).([]any); !ok {
+		return nil, ""
+	}
+	return path.IndexInt(idx), fmt.Sprintf("%s.%d", addr, idx)
+}
+
+func validationMessages(raw any) []string {
+	switch v := raw.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		msgs := make([]string, 0, len(v))
+		for _, item := range v {
+			msgs = append(msgs, validationMessages(item)...)
+		}
+		return msgs
+	case map[string]any:
+		msgs := make([]string, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			msgs = append(msgs, validationMessages(v[key])...)
+		}
+		return msgs
+	}
+	return []string{}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+package helpers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testErrorResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request: &http.Request{
+			Method: http.MethodPost,
+			URL:    &url.URL{Path: "/api/v3/flows/instances/"},
+		},
+	}
+}
+
+func testErrorResource() *schema.ResourceData {
+	return (&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Optional: true},
+			"slug": {Type: schema.TypeString, Optional: true},
+			"fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_key": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+			"redirect_uris": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"groups":        {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		},
+	}).TestResourceData()
+}
+
+func Test_HTTPToDiag_FieldErrors(t *testing.T) {
+	diags := HTTPToDiag(testErrorResource(), testErrorResponse(400, `{"slug": ["flow with this slug already exists."], "name": ["This field may not be blank.", "Invalid name."]}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 3)
+	assert.Equal(t, "This field may not be blank.", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[0].AttributePath)
+	assert.Equal(t, "Invalid name.", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("name"), diags[1].AttributePath)
+	assert.Equal(t, "flow with this slug already exists.", diags[2].Summary)
+	assert.Equal(t, cty.GetAttrPath("slug"), diags[2].AttributePath)
+	assert.Contains(t, diags[2].Detail, "POST /api/v3/flows/instances/")
+	for _, d := range diags {
+		assert.Equal(t, diag.Error, d.Severity)
+	}
+}
+
+func Test_HTTPToDiag_NestedErrors(t *testing.T) {
+	diags := HTTPToDiag(testErrorResource(), testErrorResponse(400, `{"fields": [{}, {"field_key": ["Invalid key."]}], "redirect_uris": {"1": ["Invalid URL."]}}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 2)
+	assert.Equal(t, "Invalid key.", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("fields").IndexInt(1).GetAttr("field_key"), diags[0].AttributePath)
+	assert.Equal(t, "Invalid URL.", diags[1].Summary)
+	assert.Equal(t, cty.GetAttrPath("redirect_uris").IndexInt(1), diags[1].AttributePath)
+}
+
+func Test_HTTPToDiag_UnknownAttributes(t *testing.T) {
+	// Keys which aren't attributes of the resource and indexes into sets aren't scoped to an attribute
+	diags := HTTPToDiag(testErrorResource(), testErrorResponse(400, `{"flow_set": ["Invalid."], "groups": {"0": ["Invalid group."]}, "fields": [{"unknown": ["Invalid."]}]}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 3)
+	for _, d := range diags {
+		assert.Nil(t, d.AttributePath)
+	}
+
+	// Without a resource no path can be verified
+	diags = HTTPToDiag(nil, testErrorResponse(400, `{"slug": ["Invalid."]}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+}
+
+func Test_HTTPToDiag_NonFieldErrors(t *testing.T) {
+	diags := HTTPToDiag(nil, testErrorResponse(400, `{"non_field_errors": ["The fields target, order must make a unique set."]}`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "The fields target, order must make a unique set.", diags[0].Summary)
+	assert.Nil(t, diags[0].AttributePath)
+
+	diags = HTTPToDiag(nil, testErrorResponse(400, `["Invalid request."]`), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "Invalid request.", diags[0].Summary)
+	assert.Nil(t, diags[0].AttributePath)
+}
+
+func Test_HTTPToDiag_Fallback(t *testing.T) {
+	diags := HTTPToDiag(nil, testErrorResponse(400, "mock-failed-request"), errors.New("400 Bad Request"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "HTTP Error '400 Bad Request' during request 'POST /api/v3/flows/instances/': \"mock-failed-request\"", diags[0].Summary)
+	assert.Nil(t, diags[0].AttributePath)
+
+	diags = HTTPToDiag(nil, testErrorResponse(500, `{"slug": ["error"]}`), errors.New("500 Internal Server Error"))
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+}
@@ -1,8 +1,14 @@
 package helpers
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"goauthentik.io/api/v3"
 )
@@ -22,52 +28,171 @@ type PaginatorResponse[Tobj any] interface {
 	GetPagination() api.Pagination
 }
 
-// Paginator options for page size
+// Paginator options for page size and retries of failed page fetches
 type PaginatorOptions struct {
 	PageSize int
+	// Maximum number of retries for a single page, defaults to `DefaultPageRetries`.
+	// Set to a negative value to disable retries.
+	MaxRetries int
+	// Initial wait between retries, doubled for every retry. Defaults to `DefaultPageRetryWait`.
+	RetryWait time.Duration
+	// Maximum number of pages fetched in parallel, defaults to `DefaultPageConcurrency`.
+	Concurrency int
 }
 
-const DefaultPageSize = 10
+const (
+	// Matches the default maximum page size of authentik
+	DefaultPageSize        = 100
+	DefaultPageRetries     = 3
+	DefaultPageRetryWait   = 500 * time.Millisecond
+	DefaultPageConcurrency = 4
+)
 
-// Automatically fetch all objects from an API endpoint using the pagination
-// data received from the server.
-func Paginator[Tobj any, Treq any, Tres PaginatorResponse[Tobj]](
-	req PaginatorRequest[Treq, Tres],
-	opts PaginatorOptions,
-) ([]Tobj, *http.Response, error) {
-	var bfreq, cfreq any
+// PageError Error returned when a page could not be fetched, even after retrying
+type PageError struct {
+	Endpoint string
+	Page     int32
+	Attempts int
+	Err      error
+}
+
+func (pe *PageError) Error() string {
+	return fmt.Sprintf("failed to fetch page %d of '%s' after %d attempt(s): %s", pe.Page, pe.Endpoint, pe.Attempts, pe.Err.Error())
+}
+
+func (pe *PageError) Unwrap() error {
+	return pe.Err
+}
+
+func (opts PaginatorOptions) withDefaults() PaginatorOptions {
 	if opts.PageSize < 1 {
 		opts.PageSize = DefaultPageSize
 	}
-	fetchOffset := func(page int32) (Tres, *http.Response, error) {
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultPageRetries
+	} else if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	}
+	if opts.RetryWait <= 0 {
+		opts.RetryWait = DefaultPageRetryWait
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = DefaultPageConcurrency
+	}
+	return opts
+}
+
+// Status codes which the provider's HTTP transport already retries, together with errors without a response
+var transportRetriedStatus = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// isRetryable Client errors will fail the same way when retried, and errors which the transport
+// already retried aren't retried again. Other server errors are retried.
+func isRetryable(hres *http.Response) bool {
+	return hres != nil && hres.StatusCode >= 500 && !slices.Contains(transportRetriedStatus, hres.StatusCode)
+}
+
+// fetchPage Fetch a single page, retrying a bounded number of times with exponential backoff
+func fetchPage[Treq any, Tres any](
+	ctx context.Context,
+	req PaginatorRequest[Treq, Tres],
+	page int32,
+	opts PaginatorOptions,
+) (Tres, *http.Response, error) {
+	var bfreq, cfreq any
+	wait := opts.RetryWait
+	attempt := 0
+	for {
+		attempt += 1
 		bfreq = req.Page(page)
 		cfreq = bfreq.(PaginatorRequest[Treq, Tres]).PageSize(int32(opts.PageSize))
 		res, hres, err := cfreq.(PaginatorRequest[Treq, Tres]).Execute()
-		if err != nil {
-			if hres != nil && hres.StatusCode >= 400 && hres.StatusCode < 500 {
-				return res, hres, err
+		if err == nil {
+			return res, hres, nil
+		}
+		if attempt > opts.MaxRetries || !isRetryable(hres) {
+			endpoint := "unknown endpoint"
+			if hres != nil && hres.Request != nil {
+				endpoint = fmt.Sprintf("%s %s", hres.Request.Method, hres.Request.URL.Path)
+			}
+			return res, hres, &PageError{
+				Endpoint: endpoint,
+				Page:     page,
+				Attempts: attempt,
+				Err:      err,
 			}
 		}
-		return res, hres, err
+		log.Printf("[DEBUG] authentik: failed to fetch page %d, retrying in %s: %s", page, wait, err.Error())
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, hres, ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
 	}
-	var page int32 = 1
-	errs := make([]error, 0)
-	objects := make([]Tobj, 0)
-	for {
-		apiObjects, hr, err := fetchOffset(page)
-		if err != nil {
-			if page == 1 {
-				return objects, hr, err
+}
+
+// Automatically fetch all objects from an API endpoint using the pagination
+// data received from the server. The first page is fetched to determine the total
+// number of pages, after which the remaining pages are fetched concurrently.
+func Paginator[Tobj any, Treq any, Tres PaginatorResponse[Tobj]](
+	ctx context.Context,
+	req PaginatorRequest[Treq, Tres],
+	opts PaginatorOptions,
+) ([]Tobj, *http.Response, error) {
+	opts = opts.withDefaults()
+	first, hr, err := fetchPage(ctx, req, 1, opts)
+	if err != nil {
+		return make([]Tobj, 0), hr, err
+	}
+	totalPages := int32(first.GetPagination().TotalPages)
+	pages := make([][]Tobj, max(totalPages, 1))
+	pages[0] = first.GetResults()
+
+	type pageResult struct {
+		hr  *http.Response
+		err error
+	}
+	var failed atomic.Bool
+	results := make([]pageResult, len(pages))
+	queue := make(chan int32)
+	wg := sync.WaitGroup{}
+	for range min(opts.Concurrency, len(pages)-1) {
+		wg.Go(func() {
+			for page := range queue {
+				// Don't bother fetching more pages once one has failed
+				if failed.Load() {
+					continue
+				}
+				res, hr, err := fetchPage(ctx, req, page, opts)
+				if err != nil {
+					failed.Store(true)
+					results[page-1] = pageResult{hr, err}
+					continue
+				}
+				pages[page-1] = res.GetResults()
 			}
-			errs = append(errs, err)
-			continue
-		}
-		objects = append(objects, apiObjects.GetResults()...)
-		if apiObjects.GetPagination().Next > 0 {
-			page += 1
-		} else {
-			break
+		})
+	}
+	for page := int32(2); page <= totalPages; page++ {
+		queue <- page
+	}
+	close(queue)
+	wg.Wait()
+
+	objects := make([]Tobj, 0)
+	for i, page := range pages {
+		// Return the error of the first page that failed
+		if results[i].err != nil {
+			return objects, results[i].hr, results[i].err
 		}
+		objects = append(objects, page...)
 	}
-	return objects, nil, errors.Join(errs...)
+	return objects, nil, nil
 }
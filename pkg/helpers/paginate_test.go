@@ -0,0 +1,214 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"goauthentik.io/api/v3"
+)
+
+// fakeServer Serves `total` integers in pages, failing requests for a page
+// as often as configured in `failures`
+type fakeServer struct {
+	sync.Mutex
+	total    int
+	failures map[int32]int
+	status   int
+	calls    map[int32]int
+	sizes    []int32
+	// Number of requests currently being executed, and the highest number seen
+	inFlight    int
+	maxInFlight int
+}
+
+type fakeResponse struct {
+	results    []int
+	pagination api.Pagination
+}
+
+func (fr fakeResponse) GetResults() []int {
+	return fr.results
+}
+
+func (fr fakeResponse) GetPagination() api.Pagination {
+	return fr.pagination
+}
+
+type fakeRequest struct {
+	srv      *fakeServer
+	page     int32
+	pageSize int32
+}
+
+func (fr fakeRequest) Page(page int32) fakeRequest {
+	fr.page = page
+	return fr
+}
+
+func (fr fakeRequest) PageSize(size int32) fakeRequest {
+	fr.pageSize = size
+	return fr
+}
+
+func (fr fakeRequest) Execute() (fakeResponse, *http.Response, error) {
+	fr.srv.Lock()
+	fr.srv.inFlight += 1
+	fr.srv.maxInFlight = max(fr.srv.maxInFlight, fr.srv.inFlight)
+	fr.srv.Unlock()
+	// Give other requests the chance to run concurrently
+	time.Sleep(time.Millisecond)
+
+	fr.srv.Lock()
+	defer fr.srv.Unlock()
+	fr.srv.inFlight -= 1
+	fr.srv.calls[fr.page] += 1
+	fr.srv.sizes = append(fr.srv.sizes, fr.pageSize)
+	hr := &http.Response{
+		StatusCode: http.StatusOK,
+		Request: &http.Request{
+			Method: http.MethodGet,
+			URL:    &url.URL{Path: "/api/v3/fake/"},
+		},
+	}
+	if fr.srv.failures[fr.page] > 0 {
+		fr.srv.failures[fr.page] -= 1
+		hr.StatusCode = fr.srv.status
+		return fakeResponse{}, hr, errors.New(http.StatusText(fr.srv.status))
+	}
+	res := fakeResponse{
+		results: []int{},
+	}
+	res.pagination.TotalPages = float32((fr.srv.total + int(fr.pageSize) - 1) / int(fr.pageSize))
+	start := int(fr.page-1) * int(fr.pageSize)
+	for i := start; i < start+int(fr.pageSize) && i < fr.srv.total; i++ {
+		res.results = append(res.results, i)
+	}
+	if start+int(fr.pageSize) < fr.srv.total {
+		res.pagination.Next = float32(fr.page + 1)
+	}
+	return res, hr, nil
+}
+
+func newFakeRequest(total int, status int, failures map[int32]int) (fakeRequest, *fakeServer) {
+	srv := &fakeServer{
+		total:    total,
+		failures: failures,
+		status:   status,
+		calls:    map[int32]int{},
+	}
+	return fakeRequest{srv: srv}, srv
+}
+
+func testPaginatorOptions() PaginatorOptions {
+	return PaginatorOptions{
+		PageSize:  10,
+		RetryWait: time.Millisecond,
+	}
+}
+
+func Test_Paginator(t *testing.T) {
+	req, srv := newFakeRequest(25, 0, nil)
+	objects, _, err := Paginator(t.Context(), req, testPaginatorOptions())
+	assert.NoError(t, err)
+	assert.Len(t, objects, 25)
+	assert.Equal(t, 0, objects[0])
+	assert.Equal(t, 24, objects[24])
+	assert.Equal(t, map[int32]int{1: 1, 2: 1, 3: 1}, srv.calls)
+}
+
+func Test_Paginator_Concurrent(t *testing.T) {
+	req, srv := newFakeRequest(1000, 0, nil)
+	opts := testPaginatorOptions()
+	opts.Concurrency = 3
+	objects, _, err := Paginator(t.Context(), req, opts)
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1000)
+	// Objects are returned in the order of the pages
+	for i, obj := range objects {
+		assert.Equal(t, i, obj)
+	}
+	assert.Len(t, srv.calls, 100)
+	assert.LessOrEqual(t, srv.maxInFlight, 3)
+	assert.Greater(t, srv.maxInFlight, 1)
+}
+
+func Test_Paginator_Empty(t *testing.T) {
+	req, _ := newFakeRequest(0, 0, nil)
+	objects, _, err := Paginator(t.Context(), req, testPaginatorOptions())
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func Test_Paginator_DefaultPageSize(t *testing.T) {
+	req, srv := newFakeRequest(5, 0, nil)
+	_, _, err := Paginator(t.Context(), req, PaginatorOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{DefaultPageSize}, srv.sizes)
+}
+
+func Test_Paginator_RetryTransient(t *testing.T) {
+	req, srv := newFakeRequest(25, http.StatusInternalServerError, map[int32]int{2: 2})
+	objects, _, err := Paginator(t.Context(), req, testPaginatorOptions())
+	assert.NoError(t, err)
+	assert.Len(t, objects, 25)
+	assert.Equal(t, 3, srv.calls[2])
+}
+
+func Test_Paginator_RetryExhausted(t *testing.T) {
+	req, srv := newFakeRequest(25, http.StatusInternalServerError, map[int32]int{2: 100})
+	opts := testPaginatorOptions()
+	opts.MaxRetries = 2
+	_, hr, err := Paginator(t.Context(), req, opts)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, hr.StatusCode)
+	assert.Equal(t, 3, srv.calls[2])
+
+	var pe *PageError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, int32(2), pe.Page)
+	assert.Equal(t, "GET /api/v3/fake/", pe.Endpoint)
+	assert.Contains(t, err.Error(), "page 2")
+}
+
+func Test_Paginator_NoRetryClientError(t *testing.T) {
+	req, srv := newFakeRequest(25, http.StatusBadRequest, map[int32]int{1: 1})
+	_, hr, err := Paginator(t.Context(), req, testPaginatorOptions())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, hr.StatusCode)
+	assert.Equal(t, 1, srv.calls[1])
+}
+
+func Test_Paginator_RetriesDisabled(t *testing.T) {
+	req, srv := newFakeRequest(25, http.StatusInternalServerError, map[int32]int{1: 1})
+	opts := testPaginatorOptions()
+	opts.MaxRetries = -1
+	_, _, err := Paginator(t.Context(), req, opts)
+	assert.Error(t, err)
+	assert.Equal(t, 1, srv.calls[1])
+}
+
+func Test_Paginator_NoRetryTransportRetried(t *testing.T) {
+	// Already retried by the HTTP transport
+	req, srv := newFakeRequest(25, http.StatusServiceUnavailable, map[int32]int{1: 1})
+	_, hr, err := Paginator(t.Context(), req, testPaginatorOptions())
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, hr.StatusCode)
+	assert.Equal(t, 1, srv.calls[1])
+}
+
+func Test_Paginator_RetryCanceled(t *testing.T) {
+	req, srv := newFakeRequest(25, http.StatusInternalServerError, map[int32]int{1: 100})
+	ctx, cancel := context.WithCancel(t.Context())
+	opts := testPaginatorOptions()
+	opts.RetryWait = time.Hour
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, _, err := Paginator(ctx, req, opts)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, srv.calls[1])
+}
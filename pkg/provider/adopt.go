@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// adoptExistingSchema Attribute to adopt existing objects instead of failing to create them
+func adoptExistingSchema(key string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: fmt.Sprintf("When an object with the same `%s` already exists, adopt it and update it to match the configuration instead of failing. Defaults to the provider's `adopt_existing`.", key),
+	}
+}
+
+// shouldAdopt Check if an existing object should be adopted, either configured on the resource
+// itself or on the provider
+func (c *APIClient) shouldAdopt(d *schema.ResourceData) bool {
+	if v, ok := d.GetOkExists("adopt_existing"); ok {
+		return v.(bool)
+	}
+	return c.adoptExisting
+}
+
+// isUniqueConflict Check if a request failed because an object with the same value for `field`
+// already exists. The body is kept so the response can still be converted to diagnostics.
+func isUniqueConflict(hr *http.Response, field string) bool {
+	if hr == nil || hr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	body, err := io.ReadAll(hr.Body)
+	_ = hr.Body.Close()
+	hr.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	var errs map[string]any
+	if err := json.Unmarshal(body, &errs); err != nil {
+		return false
+	}
+	messages, ok := errs[field].([]any)
+	if !ok {
+		return false
+	}
+	for _, msg := range messages {
+		if s, ok := msg.(string); ok && strings.Contains(s, "already exists") {
+			return true
+		}
+	}
+	return false
+}
+
+// adoptExisting Take ownership of an existing object and update it to match the configuration
+func adoptExisting(d *schema.ResourceData, kind string, key string, id string, update func() diag.Diagnostics) diag.Diagnostics {
+	log.Printf("[DEBUG] authentik: adopting existing %s '%s' with ID '%s'", kind, key, id)
+	d.SetId(id)
+	diags := update()
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Adopted existing %s '%s'", kind, key),
+		Detail:   fmt.Sprintf("A %s '%s' already existed and has been updated to match the configuration.", kind, key),
+	})
+}
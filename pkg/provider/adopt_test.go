@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAdoptResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestIsUniqueConflict(t *testing.T) {
+	hr := testAdoptResponse(400, `{"slug":["flow with this slug already exists."]}`)
+	assert.True(t, isUniqueConflict(hr, "slug"))
+	// Body can still be read for the error message
+	body, _ := io.ReadAll(hr.Body)
+	assert.Equal(t, `{"slug":["flow with this slug already exists."]}`, string(body))
+
+	assert.False(t, isUniqueConflict(testAdoptResponse(400, `{"slug":["flow with this slug already exists."]}`), "name"))
+	assert.False(t, isUniqueConflict(testAdoptResponse(400, `{"slug":["Enter a valid slug."]}`), "slug"))
+	assert.False(t, isUniqueConflict(testAdoptResponse(400, `mock-failed-request`), "slug"))
+	assert.False(t, isUniqueConflict(testAdoptResponse(500, `{"slug":["flow with this slug already exists."]}`), "slug"))
+	assert.False(t, isUniqueConflict(nil, "slug"))
+}
+
+func TestShouldAdopt(t *testing.T) {
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"adopt_existing": adoptExistingSchema("slug"),
+		},
+	}
+	d := res.TestResourceData()
+	assert.False(t, (&APIClient{}).shouldAdopt(d))
+	assert.True(t, (&APIClient{adoptExisting: true}).shouldAdopt(d))
+}
+
+func TestAdoptExisting(t *testing.T) {
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	diags := adoptExisting(d, "flow", "default-authentication-flow", "default-authentication-flow", func() diag.Diagnostics {
+		return nil
+	})
+	assert.Equal(t, "default-authentication-flow", d.Id())
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+}
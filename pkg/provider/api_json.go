@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// apiRequestJSON Send a request to an API endpoint for which there's no generic client method.
+// `body` is encoded as JSON when set, and the response is decoded as a JSON object.
+func (c *APIClient) apiRequestJSON(ctx context.Context, method string, path string, body any) (map[string]any, *http.Response, error) {
+	config := c.client.GetConfig()
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(config.Servers[0].URL, "/")+path, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range config.DefaultHeader {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	hr, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, hr, err
+	}
+	resBody, err := io.ReadAll(hr.Body)
+	_ = hr.Body.Close()
+	if err != nil {
+		return nil, hr, err
+	}
+	// Allow the body to be read again for error messages
+	hr.Body = io.NopCloser(bytes.NewReader(resBody))
+	if hr.StatusCode >= 300 {
+		return nil, hr, fmt.Errorf("%s", hr.Status)
+	}
+	var obj map[string]any
+	if len(resBody) == 0 {
+		return obj, hr, nil
+	}
+	if err := json.Unmarshal(resBody, &obj); err != nil {
+		return nil, hr, err
+	}
+	return obj, hr, nil
+}
+
+// apiGetJSON Get an object from an API endpoint for which there's no generic client method
+func (c *APIClient) apiGetJSON(ctx context.Context, path string) (map[string]any, *http.Response, error) {
+	return c.apiRequestJSON(ctx, http.MethodGet, path, nil)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+const (
+	attributesModeReplace = "replace"
+	attributesModeMerge   = "merge"
+)
+
+// attributesModeSchema Attribute to choose whether `attributes` are managed as a whole or only the configured keys
+func attributesModeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  attributesModeReplace,
+		Description: "How `attributes` are managed. `replace` sets the attributes to exactly the configured value. " +
+			"`merge` only sets the configured keys and keeps all other keys, for example keys written by sources or enrollment flows.",
+		ValidateDiagFunc: helpers.StringInEnum([]string{attributesModeReplace, attributesModeMerge}),
+	}
+}
+
+// managedAttributeKeysSchema Keys of `attributes` configured in `merge` mode, which are deleted when they're
+// removed from the configuration
+func managedAttributeKeysSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "Keys of `attributes` set by Terraform in `merge` mode.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// setManagedAttributeKeys Record the configured keys of `attributes` when they're managed in `merge` mode
+func setManagedAttributeKeys(d *schema.ResourceData, attr map[string]any) {
+	keys := []string{}
+	if d.Get("attributes_mode").(string) == attributesModeMerge {
+		keys = slices.Sorted(maps.Keys(attr))
+	}
+	helpers.SetWrapper(d, "managed_attribute_keys", keys)
+}
+
+// mergeAttributes Merge configured attributes into the current attributes of an object. Keys which were
+// configured before but have been removed from the configuration are deleted.
+func mergeAttributes(current map[string]any, old []string, new map[string]any) map[string]any {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+	for _, key := range old {
+		if _, ok := new[key]; !ok {
+			delete(merged, key)
+		}
+	}
+	maps.Copy(merged, new)
+	return merged
+}
+
+// resourceAttributes Get the attributes to send when updating an object. In `merge` mode, the configured keys
+// are merged into the attributes returned by `current`, and keys which were previously set in `merge` mode
+// but aren't configured anymore are deleted.
+func resourceAttributes(d *schema.ResourceData, current func() (map[string]any, diag.Diagnostics)) (map[string]any, diag.Diagnostics) {
+	attr, diags := helpers.GetJSON[map[string]any](d, "attributes")
+	if diags != nil {
+		return nil, diags
+	}
+	setManagedAttributeKeys(d, attr)
+	if d.Get("attributes_mode").(string) != attributesModeMerge {
+		return attr, nil
+	}
+	// The state holds all keys of the object when it was imported or previously managed in `replace` mode,
+	// so only keys recorded in `merge` mode are known to be set by Terraform
+	old := []string{}
+	if oldMode, _ := d.GetChange("attributes_mode"); oldMode.(string) == attributesModeMerge {
+		oldKeys, _ := d.GetChange("managed_attribute_keys")
+		for _, key := range oldKeys.(*schema.Set).List() {
+			old = append(old, key.(string))
+		}
+	}
+	cur, diags := current()
+	if diags != nil {
+		return nil, diags
+	}
+	return mergeAttributes(cur, old, attr), nil
+}
+
+// setStateAttributes Store the attributes of an object in the state. In `merge` mode, only configured keys
+// are stored, so keys written by anything else don't show up as drift.
+func setStateAttributes(d *schema.ResourceData, current map[string]any) diag.Diagnostics {
+	if d.Get("attributes_mode").(string) != attributesModeMerge {
+		return helpers.SetJSON(d, "attributes", current)
+	}
+	attr, diags := helpers.GetJSON[map[string]any](d, "attributes")
+	if diags != nil {
+		return diags
+	}
+	tracked := map[string]any{}
+	for key := range attr {
+		if value, ok := current[key]; ok {
+			tracked[key] = value
+		}
+	}
+	return helpers.SetJSON(d, "attributes", tracked)
+}
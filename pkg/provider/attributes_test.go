@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAttributes(t *testing.T) {
+	current := map[string]any{
+		"managed":  "by terraform",
+		"removed":  "previously configured",
+		"external": "written by a source",
+	}
+	merged := mergeAttributes(current, []string{"managed", "removed"}, map[string]any{
+		"managed": "updated",
+		"added":   true,
+	})
+	assert.Equal(t, map[string]any{
+		"managed":  "updated",
+		"added":    true,
+		"external": "written by a source",
+	}, merged)
+	// The current attributes are not modified
+	assert.Equal(t, "by terraform", current["managed"])
+
+	assert.Equal(t, map[string]any{"added": true}, mergeAttributes(nil, nil, map[string]any{"added": true}))
+}
+
+func TestResourceAttributes(t *testing.T) {
+	current := func() (map[string]any, diag.Diagnostics) {
+		return map[string]any{
+			"managed":  "by terraform",
+			"removed":  "previously configured",
+			"external": "written by a source",
+		}, nil
+	}
+	update := func(state map[string]string) (map[string]any, []any) {
+		d := resourceGroup().Data(&terraform.InstanceState{ID: "group", Attributes: state})
+		assert.NoError(t, d.Set("attributes_mode", attributesModeMerge))
+		assert.NoError(t, d.Set("attributes", `{"managed":"updated"}`))
+		attr, diags := resourceAttributes(d, current)
+		assert.Nil(t, diags)
+		return attr, d.Get("managed_attribute_keys").(*schema.Set).List()
+	}
+
+	// An imported object has all of its attributes in the state, none of which are known to be managed
+	attr, keys := update(map[string]string{
+		"id":         "group",
+		"attributes": `{"managed":"by terraform","removed":"previously configured","external":"written by a source"}`,
+	})
+	assert.Equal(t, map[string]any{
+		"managed":  "updated",
+		"removed":  "previously configured",
+		"external": "written by a source",
+	}, attr)
+	assert.Equal(t, []any{"managed"}, keys)
+
+	// The same goes for objects which were managed in `replace` mode
+	attr, _ = update(map[string]string{
+		"id":              "group",
+		"attributes":      `{"managed":"by terraform","removed":"previously configured","external":"written by a source"}`,
+		"attributes_mode": attributesModeReplace,
+	})
+	assert.Contains(t, attr, "removed")
+
+	// Keys previously set in `merge` mode are deleted once they're removed from the configuration
+	attr, _ = update(map[string]string{
+		"id":                       "group",
+		"attributes":               `{"managed":"by terraform","removed":"previously configured"}`,
+		"attributes_mode":          attributesModeMerge,
+		"managed_attribute_keys.#": "2",
+		"managed_attribute_keys.0": "managed",
+		"managed_attribute_keys.1": "removed",
+	})
+	assert.Equal(t, map[string]any{
+		"managed":  "updated",
+		"external": "written by a source",
+	}, attr)
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+const (
+	// Scope required for tokens issued by an OAuth2 provider to access the authentik API
+	DefaultOAuth2Scope = "goauthentik.io/api"
+
+	clientAssertionTypeJWT = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	// Refresh tokens this long before they expire, to account for clock skew and request duration
+	tokenExpiryMargin = 30 * time.Second
+	// Minimum time tokens are used for, so short-lived tokens aren't fetched again for every request.
+	// Tokens rejected before then are refreshed by the auth transport.
+	tokenMinLifetime = 10 * time.Second
+)
+
+// tokenSource Provides the token used to authenticate requests to authentik
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+	// Invalidate Called when authentik rejected the current token, so a new one is fetched
+	Invalidate()
+}
+
+type authTransport struct {
+	inner  http.RoundTripper
+	source tokenSource
+}
+
+// NewAuthTransport Get a HTTP Transport that authenticates requests with a token from `source`.
+// Requests rejected by authentik are retried once with a fresh token.
+func NewAuthTransport(inner http.RoundTripper, source tokenSource) *authTransport {
+	return &authTransport{inner, source}
+}
+
+func (at *authTransport) roundTrip(r *http.Request) (*http.Response, error) {
+	token, err := at.source.Token(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authentik token: %w", err)
+	}
+	req := r.Clone(r.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return at.inner.RoundTrip(req)
+}
+
+func (at *authTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := at.roundTrip(r)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	// The body of the request can't be re-sent
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return res, err
+	}
+	log.Printf("[DEBUG] authentik: token rejected for '%s %s', retrying with a new token", r.Method, r.URL.Path)
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+	at.source.Invalidate()
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r = r.Clone(r.Context())
+		r.Body = body
+	}
+	return at.roundTrip(r)
+}
+
+// providerCredentials Attributes which configure how the provider authenticates, only one of them can be set
+var providerCredentials = []string{"token", "token_file", "token_command", "auth"}
+
+// providerTokenSource Get the token source configured for the provider, if any.
+// Returns nil when the provider should authenticate with the static `token`.
+func providerTokenSource(d *schema.ResourceData, apiURL string, client *http.Client) (tokenSource, diag.Diagnostics) {
+	// Also checked here as values set via environment variables aren't covered by `ConflictsWith`
+	configured := []string{}
+	for _, key := range providerCredentials {
+		if _, ok := d.GetOk(key); ok {
+			configured = append(configured, fmt.Sprintf("`%s`", key))
+		}
+	}
+	if len(configured) > 1 {
+		return nil, diag.Errorf("Only one of `token`, `token_file`, `token_command` or `auth` can be set, found %s", strings.Join(configured, ", "))
+	}
+	if _, ok := d.GetOk("auth"); ok {
+		return providerOAuth2TokenSource(d, apiURL, client)
+	}
+	if command, ok := d.GetOk("token_command"); ok {
+		return &externalTokenSource{
+			load: func(ctx context.Context) (string, error) {
+				return runTokenCommand(ctx, command.(string))
+			},
+		}, nil
+	}
+	if path, ok := d.GetOk("token_file"); ok {
+		return &externalTokenSource{
+			load: func(ctx context.Context) (string, error) {
+				return readTokenFile(path.(string))
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+func providerOAuth2TokenSource(d *schema.ResourceData, apiURL string, client *http.Client) (tokenSource, diag.Diagnostics) {
+	ots := &oauth2TokenSource{
+		client:   client,
+		clientID: d.Get("auth.0.client_id").(string),
+		tokenURL: d.Get("auth.0.token_url").(string),
+		scopes:   helpers.CastSlice[string](d, "auth.0.scopes"),
+		username: d.Get("auth.0.username").(string),
+		password: d.Get("auth.0.password").(string),
+		jwt:      d.Get("auth.0.jwt").(string),
+		jwtFile:  d.Get("auth.0.jwt_file").(string),
+	}
+	configured := 0
+	for _, v := range []string{ots.username, ots.jwt, ots.jwtFile} {
+		if v != "" {
+			configured += 1
+		}
+	}
+	if configured != 1 {
+		return nil, diag.Errorf("Exactly one of `username`, `jwt` or `jwt_file` must be set in the `auth` block")
+	}
+	if ots.username != "" && ots.password == "" {
+		return nil, diag.Errorf("`password` must be set in the `auth` block when `username` is set")
+	}
+	if len(ots.scopes) < 1 {
+		ots.scopes = []string{DefaultOAuth2Scope}
+	}
+	if ots.tokenURL == "" {
+		base, err := url.Parse(apiURL)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		base.Path = strings.TrimSuffix(strings.TrimSuffix(base.Path, "/"), "/api/v3")
+		ots.tokenURL = base.JoinPath("/application/o/token/").String()
+	}
+	return ots, nil
+}
+
+// externalTokenSource Token source for a static token stored outside of the configuration,
+// which is loaded again when authentik rejects it so that rotated tokens take effect
+type externalTokenSource struct {
+	load func(ctx context.Context) (string, error)
+
+	m     sync.Mutex
+	token string
+}
+
+func (ets *externalTokenSource) Token(ctx context.Context) (string, error) {
+	ets.m.Lock()
+	defer ets.m.Unlock()
+	if ets.token != "" {
+		return ets.token, nil
+	}
+	token, err := ets.load(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", errors.New("token is empty")
+	}
+	ets.token = token
+	return ets.token, nil
+}
+
+func (ets *externalTokenSource) Invalidate() {
+	ets.m.Lock()
+	defer ets.m.Unlock()
+	ets.token = ""
+}
+
+func readTokenFile(path string) (string, error) {
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// runTokenCommand Run `command` in the system shell and return its output as token
+func runTokenCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("token command failed: %w: \"%s\"", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// oauth2TokenSource Token source that uses the OAuth2 client_credentials grant
+// to get short-lived tokens from an authentik OAuth2 provider
+type oauth2TokenSource struct {
+	client   *http.Client
+	tokenURL string
+	clientID string
+	scopes   []string
+	// Authenticate using the credentials of a service account
+	username string
+	password string
+	// Authenticate using a JWT issued by a federated source or provider, either
+	// passed directly or read from a file on every refresh
+	jwt     string
+	jwtFile string
+
+	m     sync.Mutex
+	token string
+	// When the token has to be refreshed, zero if the token doesn't expire
+	refreshAt time.Time
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (ots *oauth2TokenSource) Token(ctx context.Context) (string, error) {
+	ots.m.Lock()
+	defer ots.m.Unlock()
+	if ots.token != "" && (ots.refreshAt.IsZero() || time.Now().Before(ots.refreshAt)) {
+		return ots.token, nil
+	}
+	res, err := ots.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	ots.token = res.AccessToken
+	ots.refreshAt = tokenRefreshAt(time.Now(), res.ExpiresIn)
+	return ots.token, nil
+}
+
+// tokenRefreshAt Get when a token issued at `now` which expires in `expiresIn` seconds has to be refreshed.
+// Tokens without expiry are used until authentik rejects them.
+func tokenRefreshAt(now time.Time, expiresIn int) time.Time {
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+	lifetime := time.Duration(expiresIn)*time.Second - tokenExpiryMargin
+	return now.Add(max(lifetime, tokenMinLifetime))
+}
+
+func (ots *oauth2TokenSource) Invalidate() {
+	ots.m.Lock()
+	defer ots.m.Unlock()
+	ots.token = ""
+}
+
+func (ots *oauth2TokenSource) assertion() (string, error) {
+	if ots.jwtFile == "" {
+		return ots.jwt, nil
+	}
+	jwt, err := os.ReadFile(ots.jwtFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(jwt)), nil
+}
+
+func (ots *oauth2TokenSource) fetch(ctx context.Context) (*oauth2TokenResponse, error) {
+	form := url.Values{
+		"grant_type": []string{"client_credentials"},
+		"client_id":  []string{ots.clientID},
+		"scope":      []string{strings.Join(ots.scopes, " ")},
+	}
+	if ots.username != "" {
+		form.Set("username", ots.username)
+		form.Set("password", ots.password)
+	} else {
+		jwt, err := ots.assertion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT: %w", err)
+		}
+		form.Set("client_assertion_type", clientAssertionTypeJWT)
+		form.Set("client_assertion", jwt)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ots.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	hr, err := ots.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = hr.Body.Close()
+	}()
+	body, err := io.ReadAll(hr.Body)
+	if err != nil {
+		return nil, err
+	}
+	if hr.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request to '%s' failed with status %d: \"%s\"", ots.tokenURL, hr.StatusCode, string(body))
+	}
+	var res oauth2TokenResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+	if res.AccessToken == "" {
+		return nil, errors.New("token response did not contain an access token")
+	}
+	return &res, nil
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testOAuth2Server Serves a token endpoint issuing a new token for every request,
+// and an API endpoint which only accepts the latest token
+func testOAuth2Server(t *testing.T, expiresIn int, check func(r *http.Request)) (*httptest.Server, *atomic.Int32) {
+	issued := &atomic.Int32{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/application/o/token/", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "test-client", r.PostForm.Get("client_id"))
+		assert.Equal(t, DefaultOAuth2Scope, r.PostForm.Get("scope"))
+		check(r)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(oauth2TokenResponse{
+			AccessToken: fmt.Sprintf("token-%d", issued.Add(1)),
+			TokenType:   "Bearer",
+			ExpiresIn:   expiresIn,
+		})
+	})
+	mux.HandleFunc("/api/v3/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", issued.Load()) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, issued
+}
+
+func TestOAuth2TokenSource_ServiceAccount(t *testing.T) {
+	srv, issued := testOAuth2Server(t, 3600, func(r *http.Request) {
+		assert.Equal(t, "sa", r.PostForm.Get("username"))
+		assert.Equal(t, "app-password", r.PostForm.Get("password"))
+	})
+	client := &http.Client{
+		Transport: NewAuthTransport(http.DefaultTransport, &oauth2TokenSource{
+			client:   http.DefaultClient,
+			tokenURL: srv.URL + "/application/o/token/",
+			clientID: "test-client",
+			scopes:   []string{DefaultOAuth2Scope},
+			username: "sa",
+			password: "app-password",
+		}),
+	}
+	for range 3 {
+		res, err := client.Get(srv.URL + "/api/v3/")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+	// Token is re-used until it expires
+	assert.Equal(t, int32(1), issued.Load())
+}
+
+func TestOAuth2TokenSource_JWTFile(t *testing.T) {
+	jwtFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(jwtFile, []byte("first-jwt\n"), 0o600))
+	expected := &atomic.Value{}
+	expected.Store("first-jwt")
+	srv, issued := testOAuth2Server(t, 1, func(r *http.Request) {
+		assert.Equal(t, clientAssertionTypeJWT, r.PostForm.Get("client_assertion_type"))
+		assert.Equal(t, expected.Load(), r.PostForm.Get("client_assertion"))
+	})
+	source := &oauth2TokenSource{
+		client:   http.DefaultClient,
+		tokenURL: srv.URL + "/application/o/token/",
+		clientID: "test-client",
+		scopes:   []string{DefaultOAuth2Scope},
+		jwtFile:  jwtFile,
+	}
+	client := &http.Client{
+		Transport: NewAuthTransport(http.DefaultTransport, source),
+	}
+	res, err := client.Get(srv.URL + "/api/v3/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// Expired tokens are refreshed, reading the JWT again
+	source.refreshAt = time.Now().Add(-time.Second)
+	assert.NoError(t, os.WriteFile(jwtFile, []byte("second-jwt"), 0o600))
+	expected.Store("second-jwt")
+	res, err = client.Get(srv.URL + "/api/v3/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestAuthTransport_RetryUnauthorized(t *testing.T) {
+	srv, issued := testOAuth2Server(t, 3600, func(r *http.Request) {})
+	source := &oauth2TokenSource{
+		client:   http.DefaultClient,
+		tokenURL: srv.URL + "/application/o/token/",
+		clientID: "test-client",
+		scopes:   []string{DefaultOAuth2Scope},
+		jwt:      "jwt",
+	}
+	client := &http.Client{
+		Transport: NewAuthTransport(http.DefaultTransport, source),
+	}
+	res, err := client.Get(srv.URL + "/api/v3/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// Simulate the token being revoked server-side
+	issued.Add(1)
+	res, err = client.Get(srv.URL + "/api/v3/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(3), issued.Load())
+}
+
+func TestExternalTokenSource_TokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("first-token\n"), 0o600))
+	valid := &atomic.Value{}
+	valid.Store("first-token")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", valid.Load()) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	client := &http.Client{
+		Transport: NewAuthTransport(http.DefaultTransport, &externalTokenSource{
+			load: func(ctx context.Context) (string, error) {
+				return readTokenFile(tokenFile)
+			},
+		}),
+	}
+	res, err := client.Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// Rotate the token, the file is read again after the old token is rejected
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("second-token"), 0o600))
+	valid.Store("second-token")
+	res, err = client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a POSIX shell")
+	}
+	token, err := runTokenCommand(t.Context(), "echo my-token")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", token)
+
+	_, err = runTokenCommand(t.Context(), "echo failed >&2; exit 1")
+	assert.ErrorContains(t, err, "failed")
+}
+
+func TestTokenRefreshAt(t *testing.T) {
+	now := time.Now()
+	assert.True(t, tokenRefreshAt(now, 0).IsZero())
+	assert.Equal(t, now.Add(3600*time.Second-tokenExpiryMargin), tokenRefreshAt(now, 3600))
+	// Short-lived tokens are still re-used for a minimum time
+	assert.Equal(t, now.Add(tokenMinLifetime), tokenRefreshAt(now, 5))
+}
+
+func TestProviderTokenSource_Conflicts(t *testing.T) {
+	for _, env := range []string{"AUTHENTIK_TOKEN", "AUTHENTIK_TOKEN_FILE", "AUTHENTIK_TOKEN_COMMAND"} {
+		t.Setenv(env, "")
+	}
+	p := Provider("testing", true)
+
+	source, diags := providerTokenSource(schema.TestResourceDataRaw(t, p.Schema, map[string]any{
+		"token": "static-token",
+	}), "https://authentik.company", http.DefaultClient)
+	assert.Nil(t, diags)
+	assert.Nil(t, source)
+
+	// Credentials set via environment variables conflict with the configuration as well
+	t.Setenv("AUTHENTIK_TOKEN_FILE", "/run/secrets/authentik")
+	_, diags = providerTokenSource(schema.TestResourceDataRaw(t, p.Schema, map[string]any{
+		"token": "static-token",
+	}), "https://authentik.company", http.DefaultClient)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Only one of `token`, `token_file`, `token_command` or `auth` can be set, found `token`, `token_file`", diags[0].Summary)
+}
@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+	"gopkg.in/yaml.v3"
+)
+
+// Read-only fields which are left out of exported objects
+var blueprintExportReadOnly = []string{"pk", "meta_model_name", "component", "verbose_name", "verbose_name_plural"}
+
+func dataSourceBlueprintExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBlueprintExportRead,
+		Description: "Blueprints --- Export flows, applications and providers as a blueprint, which can be used to restore them without Terraform.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Terraform export",
+				Description: "Name of the generated blueprint.",
+			},
+			"flows": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Slugs of the flows to export. Flows are exported including their stages, prompts and bindings.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"applications": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Slugs of the applications to export.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"providers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "IDs of the providers to export.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Blueprint YAML containing all exported objects, including secrets such as client secrets of providers.",
+			},
+		},
+	}
+}
+
+// blueprintExportKeyOf Get a `!KeyOf` tag referencing another entry
+func blueprintExportKeyOf(id string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!KeyOf", Value: id}
+}
+
+// blueprintExportFind Get a `!Find` tag to look up an object by a single field
+func blueprintExportFind(model string, field string, value string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.SequenceNode,
+		Tag:   "!Find",
+		Style: yaml.FlowStyle,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: model},
+			{
+				Kind:  yaml.SequenceNode,
+				Style: yaml.FlowStyle,
+				Content: []*yaml.Node{
+					{Kind: yaml.ScalarNode, Value: field},
+					{Kind: yaml.ScalarNode, Value: value},
+				},
+			},
+		},
+	}
+}
+
+// blueprintExportProviderRef Get a reference to a provider, either a `!KeyOf` tag if the provider is exported
+// as well, or a `!Find` tag using its type and name from `providerObj`. Returns nil if neither is possible.
+func blueprintExportProviderRef(pk string, providerObj map[string]any, providers map[string]bool) *yaml.Node {
+	if providers[pk] {
+		return blueprintExportKeyOf("provider-" + pk)
+	}
+	if providerObj == nil {
+		return nil
+	}
+	return blueprintExportFind(fmt.Sprint(providerObj["meta_model_name"]), "name", fmt.Sprint(providerObj["name"]))
+}
+
+// blueprintExportApplicationRefs Get references to the providers of an application, so their primary keys
+// which differ between instances aren't exported
+func blueprintExportApplicationRefs(obj map[string]any, providers map[string]bool) map[string]*yaml.Node {
+	refs := map[string]*yaml.Node{}
+	if provider, ok := obj["provider"]; ok && provider != nil {
+		providerObj, _ := obj["provider_obj"].(map[string]any)
+		if ref := blueprintExportProviderRef(fmt.Sprint(provider), providerObj, providers); ref != nil {
+			refs["provider"] = ref
+		}
+	}
+	backchannel, _ := obj["backchannel_providers"].([]any)
+	if len(backchannel) < 1 {
+		return refs
+	}
+	backchannelObjs := map[string]map[string]any{}
+	objs, _ := obj["backchannel_providers_obj"].([]any)
+	for _, o := range objs {
+		if providerObj, ok := o.(map[string]any); ok {
+			backchannelObjs[fmt.Sprint(providerObj["pk"])] = providerObj
+		}
+	}
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, provider := range backchannel {
+		pk := fmt.Sprint(provider)
+		ref := blueprintExportProviderRef(pk, backchannelObjs[pk], providers)
+		if ref == nil {
+			// Keep the primary key if the provider can't be referenced otherwise
+			ref = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: pk}
+		}
+		seq.Content = append(seq.Content, ref)
+	}
+	refs["backchannel_providers"] = seq
+	return refs
+}
+
+// blueprintExportEntry Build a blueprint entry for an object read from the API. Flows are referenced by
+// their slug instead of their UUID and references to other exported objects are replaced with `!KeyOf` tags.
+func blueprintExportEntry(model string, id string, identifiers map[string]any, obj map[string]any, flows map[string]string, refs map[string]*yaml.Node) (*yaml.Node, error) {
+	attrs := &yaml.Node{Kind: yaml.MappingNode}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := obj[key]
+		if _, ok := identifiers[key]; ok || slices.Contains(blueprintExportReadOnly, key) || strings.HasSuffix(key, "_obj") {
+			continue
+		}
+		node := &yaml.Node{}
+		if ref, ok := refs[key]; ok {
+			node = ref
+		} else if slug, ok := flows[fmt.Sprint(value)]; ok && strings.HasSuffix(key, "_flow") {
+			node = blueprintExportFind("authentik_flows.flow", "slug", slug)
+		} else if err := node.Encode(value); err != nil {
+			return nil, err
+		}
+		attrs.Content = append(attrs.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, node)
+	}
+	idents := &yaml.Node{}
+	if err := idents.Encode(identifiers); err != nil {
+		return nil, err
+	}
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "model"}, {Kind: yaml.ScalarNode, Value: model},
+			{Kind: yaml.ScalarNode, Value: "id"}, {Kind: yaml.ScalarNode, Value: id},
+			{Kind: yaml.ScalarNode, Value: "identifiers"}, idents,
+			{Kind: yaml.ScalarNode, Value: "attrs"}, attrs,
+		},
+	}, nil
+}
+
+// dataSourceBlueprintExportFlow Get the entries of a flow from the flow export endpoint
+func dataSourceBlueprintExportFlow(ctx context.Context, d *schema.ResourceData, c *APIClient, slug string) ([]*yaml.Node, diag.Diagnostics) {
+	f, hr, err := c.client.FlowsAPI.FlowsInstancesExportRetrieve(ctx, slug).Execute()
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, diag.Errorf("failed to parse export of flow '%s': %s", slug, err.Error())
+	}
+	if len(doc.Content) < 1 {
+		return nil, diag.Errorf("export of flow '%s' is empty", slug)
+	}
+	for i := 0; i+1 < len(doc.Content[0].Content); i += 2 {
+		if doc.Content[0].Content[i].Value == "entries" {
+			return doc.Content[0].Content[i+1].Content, nil
+		}
+	}
+	return nil, diag.Errorf("export of flow '%s' does not contain any entries", slug)
+}
+
+func dataSourceBlueprintExportRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+	entries := &yaml.Node{Kind: yaml.SequenceNode}
+
+	for _, slug := range helpers.CastSlice[string](d, "flows") {
+		flowEntries, diags := dataSourceBlueprintExportFlow(ctx, d, c, slug)
+		if diags.HasError() {
+			return diags
+		}
+		entries.Content = append(entries.Content, flowEntries...)
+	}
+
+	// Flows are referenced by their UUID, which changes when they're restored on a different instance
+	flows := map[string]string{}
+	allFlows, hr, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsInstancesList(ctx), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	for _, f := range allFlows {
+		flows[f.Pk] = f.Slug
+	}
+
+	providers := map[string]bool{}
+	for _, pk := range helpers.CastSlice[int](d, "providers") {
+		generic, hr, err := c.apiGetJSON(ctx, fmt.Sprintf("/providers/all/%d/", pk))
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		model, ok := generic["meta_model_name"].(string)
+		if !ok {
+			return diag.Errorf("failed to get type of provider %d", pk)
+		}
+		app, _, _ := strings.Cut(model, ".")
+		obj, hr, err := c.apiGetJSON(ctx, fmt.Sprintf("/providers/%s/%d/", strings.TrimPrefix(app, "authentik_providers_"), pk))
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		entry, err := blueprintExportEntry(model, fmt.Sprintf("provider-%d", pk), map[string]any{"name": obj["name"]}, obj, flows, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		entries.Content = append(entries.Content, entry)
+		providers[strconv.Itoa(pk)] = true
+	}
+
+	for _, slug := range helpers.CastSlice[string](d, "applications") {
+		obj, hr, err := c.apiGetJSON(ctx, fmt.Sprintf("/core/applications/%s/", slug))
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		refs := blueprintExportApplicationRefs(obj, providers)
+		entry, err := blueprintExportEntry("authentik_core.application", "application-"+slug, map[string]any{"slug": slug}, obj, flows, refs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		entries.Content = append(entries.Content, entry)
+	}
+
+	blueprint := map[string]any{
+		"version": 1,
+		"metadata": map[string]any{
+			"name": d.Get("name").(string),
+			"labels": map[string]any{
+				"blueprints.goauthentik.io/instantiate": "false",
+			},
+		},
+	}
+	doc := &yaml.Node{}
+	if err := doc.Encode(blueprint); err != nil {
+		return diag.FromErr(err)
+	}
+	doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "entries"}, entries)
+	content, err := yaml.Marshal(doc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(content)))
+	helpers.SetWrapper(d, "content", string(content))
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAccDataSourceBlueprintExport(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBlueprintExportSimple,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.authentik_blueprint_export.export", "content", regexp.MustCompile("model: authentik_flows.flow")),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceBlueprintExportSimple = `
+data "authentik_blueprint_export" "export" {
+  flows = ["default-authentication-flow"]
+}
+`
+
+func TestBlueprintExportEntry(t *testing.T) {
+	entry, err := blueprintExportEntry(
+		"authentik_core.application",
+		"application-app",
+		map[string]any{"slug": "app"},
+		map[string]any{
+			"pk":                  "1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71",
+			"slug":                "app",
+			"name":                "App",
+			"provider":            float64(3),
+			"provider_obj":        map[string]any{"name": "oauth"},
+			"meta_launch_url":     "https://app.example.com",
+			"authentication_flow": "0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11",
+		},
+		map[string]string{"0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11": "default-authentication-flow"},
+		map[string]*yaml.Node{"provider": blueprintExportKeyOf("provider-3")},
+	)
+	assert.NoError(t, err)
+	out, err := yaml.Marshal(entry)
+	assert.NoError(t, err)
+	assert.Equal(t, `model: authentik_core.application
+id: application-app
+identifiers:
+    slug: app
+attrs:
+    authentication_flow: !Find [authentik_flows.flow, [slug, default-authentication-flow]]
+    meta_launch_url: https://app.example.com
+    name: App
+    provider: !KeyOf provider-3
+`, string(out))
+}
+
+func TestBlueprintExportApplicationRefs(t *testing.T) {
+	refs := blueprintExportApplicationRefs(map[string]any{
+		"provider":              float64(3),
+		"provider_obj":          map[string]any{"pk": float64(3), "name": "oauth", "meta_model_name": "authentik_providers_oauth2.oauth2provider"},
+		"backchannel_providers": []any{float64(3), float64(4), float64(5)},
+		"backchannel_providers_obj": []any{
+			map[string]any{"pk": float64(4), "name": "scim", "meta_model_name": "authentik_providers_scim.scimprovider"},
+		},
+	}, map[string]bool{"3": true})
+	out, err := yaml.Marshal(refs)
+	assert.NoError(t, err)
+	assert.Equal(t, `backchannel_providers:
+    - !KeyOf provider-3
+    - !Find [authentik_providers_scim.scimprovider, [name, scim]]
+    - 5
+provider: !KeyOf provider-3
+`, string(out))
+}
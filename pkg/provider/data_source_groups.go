@@ -119,25 +119,20 @@ func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, m any) di
 		}
 	}
 
-	groups := make([]map[string]any, 0)
-	for page := int32(1); true; page++ {
-		req = req.Page(page)
-		res, hr, err := req.Execute()
-		if err != nil {
-			return helpers.HTTPToDiag(d, hr, err)
-		}
-
-		for _, groupRes := range res.Results {
-			u, err := mapFromGroup(groupRes)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			groups = append(groups, u)
-		}
+	res, hr, err := helpers.Paginator(ctx, req, helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
 
-		if res.Pagination.Next == 0 {
-			break
+	groups := make([]map[string]any, len(res))
+	for i, groupRes := range res {
+		u, err := mapFromGroup(groupRes)
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		groups[i] = u
 	}
 
 	d.SetId("0")
@@ -66,23 +66,25 @@ func dataSourcePropertyMappingProviderRadiusRead(ctx context.Context, d *schema.
 		req = req.Name(n.(string))
 	}
 
-	res, hr, err := req.Execute()
+	res, hr, err := helpers.Paginator(ctx, req, helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
 
-	if len(res.Results) < 1 {
+	if len(res) < 1 {
 		return diag.Errorf("No matching mappings found")
 	}
 	if _, ok := d.GetOk("managed_list"); ok {
 		d.SetId("-1")
-		ids := make([]string, len(res.Results))
-		for i, r := range res.Results {
+		ids := make([]string, len(res))
+		for i, r := range res {
 			ids[i] = r.Pk
 		}
 		helpers.SetWrapper(d, "ids", ids)
 	} else {
-		f := res.Results[0]
+		f := res[0]
 		d.SetId(f.Pk)
 		helpers.SetWrapper(d, "name", f.Name)
 		helpers.SetWrapper(d, "name", f.Name)
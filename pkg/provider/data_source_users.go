@@ -129,25 +129,20 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m any) dia
 		}
 	}
 
-	users := make([]map[string]any, 0)
-	for page := int32(1); true; page++ {
-		req = req.Page(page)
-		res, hr, err := req.Execute()
-		if err != nil {
-			return helpers.HTTPToDiag(d, hr, err)
-		}
-
-		for _, userRes := range res.Results {
-			u, err := mapFromUser(userRes)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-			users = append(users, u)
-		}
+	res, hr, err := helpers.Paginator(ctx, req, helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
 
-		if res.Pagination.Next == 0 {
-			break
+	users := make([]map[string]any, len(res))
+	for i, userRes := range res {
+		u, err := mapFromUser(userRes)
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		users[i] = u
 	}
 
 	d.SetId("0")
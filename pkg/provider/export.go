@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+// Prefixes of the app label of a model, mapped to the prefix of the matching resource type
+var exportModelPrefixes = map[string]string{
+	"authentik_stages_":    "authentik_stage_",
+	"authentik_policies_":  "authentik_policy_",
+	"authentik_providers_": "authentik_provider_",
+}
+
+// App labels which don't match the name of their resource type
+var exportModelOverrides = map[string]string{
+	"authentik_stages_mtls": "authentik_stage_mutual_tls",
+}
+
+// exportCategory A group of objects written to the same file
+type exportCategory struct {
+	file string
+	list func(ctx context.Context, c *APIClient) ([]*exportObject, error)
+}
+
+var exportCategories = []exportCategory{
+	{file: "flows.tf", list: exportListFlows},
+	{file: "stages.tf", list: exportListStages},
+	{file: "flow_stage_bindings.tf", list: exportListFlowStageBindings},
+	{file: "policies.tf", list: exportListPolicies},
+	{file: "policy_bindings.tf", list: exportListPolicyBindings},
+	{file: "providers.tf", list: exportListProviders},
+	{file: "applications.tf", list: exportListApplications},
+	{file: "groups.tf", list: exportListGroups},
+}
+
+// exportModelResourceType Get the resource type for a model name like `authentik_stages_dummy.dummystage`
+func exportModelResourceType(model string) string {
+	app, _, _ := strings.Cut(model, ".")
+	if rt, ok := exportModelOverrides[app]; ok {
+		return rt
+	}
+	for prefix, rtPrefix := range exportModelPrefixes {
+		if after, ok := strings.CutPrefix(app, prefix); ok {
+			return rtPrefix + after
+		}
+	}
+	return ""
+}
+
+// Export Read flows, stages, bindings, policies, providers, applications and groups from the authentik
+// instance configured with the provider's environment variables, and write them to `dir` as Terraform
+// configuration with an import block for every resource.
+func Export(ctx context.Context, p *schema.Provider, dir string) diag.Diagnostics {
+	diags := p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]any{}))
+	if diags.HasError() {
+		return diags
+	}
+	c := p.Meta().(*APIClient)
+
+	refs := exportReferences{}
+	objects := map[string][]*exportObject{}
+	names := map[string]map[string]bool{}
+	for _, cat := range exportCategories {
+		listed, err := cat.list(ctx, c)
+		if err != nil {
+			return append(diags, diag.Errorf("failed to list objects for %s: %s", cat.file, err.Error())...)
+		}
+		for _, o := range listed {
+			res, ok := p.ResourcesMap[o.resourceType]
+			if o.resourceType == "" || !ok {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Skipping '%s' (%s), its type is not supported", o.displayName, o.id),
+				})
+				continue
+			}
+			o.data = res.Data(nil)
+			o.data.SetId(o.id)
+			if rd := res.ReadContext(ctx, o.data, c); rd.HasError() {
+				return append(diags, rd...)
+			}
+			// Object was deleted in the meantime
+			if o.data.Id() == "" {
+				continue
+			}
+			if names[o.resourceType] == nil {
+				names[o.resourceType] = map[string]bool{}
+			}
+			o.name = exportName(o.displayName, names[o.resourceType])
+			refs.add(o, res)
+			objects[cat.file] = append(objects[cat.file], o)
+		}
+	}
+
+	imports := hclwrite.NewEmptyFile()
+	vars := []exportVariable{}
+	for _, cat := range exportCategories {
+		if len(objects[cat.file]) < 1 {
+			continue
+		}
+		f := hclwrite.NewEmptyFile()
+		for i, o := range objects[cat.file] {
+			if i > 0 {
+				f.Body().AppendNewline()
+				imports.Body().AppendNewline()
+			}
+			vars = append(vars, exportWriteResource(f.Body(), o, p.ResourcesMap[o.resourceType], refs)...)
+			exportWriteImport(imports.Body(), o)
+		}
+		if err := os.WriteFile(filepath.Join(dir, cat.file), f.Bytes(), 0o644); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "imports.tf"), imports.Bytes(), 0o644); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "provider.tf"), exportProviderConfig(), 0o644); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if len(vars) > 0 {
+		f := hclwrite.NewEmptyFile()
+		exportWriteVariables(f.Body(), vars)
+		if err := os.WriteFile(filepath.Join(dir, "variables.tf"), f.Bytes(), 0o644); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d sensitive values were not exported", len(vars)),
+			Detail:   "Sensitive values such as client secrets and passwords are referenced as variables declared in variables.tf, which have to be set before applying the configuration.",
+		})
+	}
+	return diags
+}
+
+// exportProviderConfig Get the configuration required to use the provider, which is configured with
+// the same environment variables used for the export
+func exportProviderConfig() []byte {
+	f := hclwrite.NewEmptyFile()
+	tf := f.Body().AppendNewBlock("terraform", nil)
+	providers := tf.Body().AppendNewBlock("required_providers", nil)
+	providers.Body().SetAttributeValue("authentik", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal("goauthentik/authentik"),
+	}))
+	f.Body().AppendNewline()
+	f.Body().AppendNewBlock("provider", []string{"authentik"})
+	return f.Bytes()
+}
+
+func exportListFlows(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsInstancesList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, f := range res {
+		objects[i] = &exportObject{resourceType: "authentik_flow", id: f.Slug, displayName: f.Slug}
+	}
+	return objects, nil
+}
+
+func exportListStages(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.StagesAPI.StagesAllList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, s := range res {
+		objects[i] = &exportObject{resourceType: exportModelResourceType(s.MetaModelName), id: s.Pk, displayName: s.Name}
+	}
+	return objects, nil
+}
+
+func exportListFlowStageBindings(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsBindingsList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, b := range res {
+		objects[i] = &exportObject{
+			resourceType: "authentik_flow_stage_binding",
+			id:           b.Pk,
+			displayName:  fmt.Sprintf("%s_%d", b.StageObj.Name, b.Order),
+		}
+	}
+	return objects, nil
+}
+
+func exportListPolicies(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.PoliciesAPI.PoliciesAllList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, p := range res {
+		objects[i] = &exportObject{resourceType: exportModelResourceType(p.MetaModelName), id: p.Pk, displayName: p.Name}
+	}
+	return objects, nil
+}
+
+func exportListPolicyBindings(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.PoliciesAPI.PoliciesBindingsList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, b := range res {
+		objects[i] = &exportObject{
+			resourceType: "authentik_policy_binding",
+			id:           b.Pk,
+			displayName:  fmt.Sprintf("binding_%s_%d", strings.Split(b.Pk, "-")[0], b.Order),
+		}
+	}
+	return objects, nil
+}
+
+func exportListProviders(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.ProvidersAPI.ProvidersAllList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, p := range res {
+		objects[i] = &exportObject{
+			resourceType: exportModelResourceType(p.MetaModelName),
+			id:           strconv.Itoa(int(p.Pk)),
+			displayName:  p.Name,
+		}
+	}
+	return objects, nil
+}
+
+func exportListApplications(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreApplicationsList(ctx), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, a := range res {
+		objects[i] = &exportObject{resourceType: "authentik_application", id: a.Slug, displayName: a.Slug}
+	}
+	return objects, nil
+}
+
+func exportListGroups(ctx context.Context, c *APIClient) ([]*exportObject, error) {
+	res, _, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreGroupsList(ctx).IncludeUsers(false), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]*exportObject, len(res))
+	for i, g := range res {
+		objects[i] = &exportObject{resourceType: "authentik_group", id: g.Pk, displayName: g.Name}
+	}
+	return objects, nil
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	exportUUID        = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	exportInvalidName = regexp.MustCompile(`[^a-z0-9_-]+`)
+)
+
+// Integer attributes which reference providers by their ID
+var exportProviderAttributes = []string{"protocol_provider", "backchannel_providers"}
+
+// exportObject An object read from authentik, written as a resource with an import block
+type exportObject struct {
+	resourceType string
+	id           string
+	// Name of the object in authentik, used to derive the resource name
+	displayName string
+	name        string
+	data        *schema.ResourceData
+}
+
+func (o *exportObject) traversal(attr string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: o.resourceType},
+		hcl.TraverseAttr{Name: o.name},
+		hcl.TraverseAttr{Name: attr},
+	}
+}
+
+// exportReferences Maps the IDs of exported objects to a reference to their resource,
+// so cross-references are written as references instead of raw IDs
+type exportReferences map[string]hcl.Traversal
+
+func exportProviderKey(id string) string {
+	return "provider/" + id
+}
+
+func (refs exportReferences) add(o *exportObject, res *schema.Resource) {
+	if strings.HasPrefix(o.resourceType, "authentik_provider_") {
+		refs[exportProviderKey(o.id)] = o.traversal("id")
+	} else {
+		refs[o.id] = o.traversal("id")
+	}
+	// Flows and applications are referenced by their UUID, not by their ID
+	if _, ok := res.Schema["uuid"]; ok {
+		refs[o.data.Get("uuid").(string)] = o.traversal("uuid")
+	}
+}
+
+// exportName Convert the name of an object into a valid resource name, unique for the resource type
+func exportName(name string, used map[string]bool) string {
+	base := strings.Trim(exportInvalidName.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if base == "" {
+		base = "object"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+	name = base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// exportIsDefault Check if a value can be omitted from the configuration, as it's either the
+// default value of the attribute or empty
+func exportIsDefault(s *schema.Schema, v any) bool {
+	if s.Default != nil {
+		return fmt.Sprint(s.Default) == fmt.Sprint(v)
+	}
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case *schema.Set:
+		return vv.Len() < 1
+	case []any:
+		return len(vv) < 1
+	case map[string]any:
+		return len(vv) < 1
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// exportVariable A sensitive value which is not written to the configuration, but referenced as variable
+type exportVariable struct {
+	name        string
+	description string
+}
+
+// exportWriteResource Append a resource block for `o` to `body`. Returns the variables which are
+// referenced instead of sensitive values.
+func exportWriteResource(body *hclwrite.Body, o *exportObject, res *schema.Resource, refs exportReferences) []exportVariable {
+	block := body.AppendNewBlock("resource", []string{o.resourceType, o.name})
+	values := map[string]any{}
+	for key := range res.Schema {
+		values[key] = o.data.Get(key)
+	}
+	return exportWriteBody(block.Body(), res.Schema, values, refs, o.resourceType+"."+o.name)
+}
+
+// exportWriteVariables Append a sensitive variable block for every variable to `body`
+func exportWriteVariables(body *hclwrite.Body, vars []exportVariable) {
+	for i, v := range vars {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("variable", []string{v.name})
+		block.Body().SetAttributeValue("description", cty.StringVal(v.description))
+		block.Body().SetAttributeValue("sensitive", cty.True)
+	}
+}
+
+// exportWriteImport Append an import block for `o` to `body`
+func exportWriteImport(body *hclwrite.Body, o *exportObject) {
+	block := body.AppendNewBlock("import", nil)
+	block.Body().SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: o.resourceType},
+		hcl.TraverseAttr{Name: o.name},
+	})
+	block.Body().SetAttributeValue("id", cty.StringVal(o.id))
+}
+
+func exportWriteBody(body *hclwrite.Body, sm map[string]*schema.Schema, values map[string]any, refs exportReferences, address string) []exportVariable {
+	vars := []exportVariable{}
+	keys := make([]string, 0, len(sm))
+	for key := range sm {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		s := sm[key]
+		// Skip attributes which can't be configured
+		if (!s.Required && !s.Optional) || s.Deprecated != "" {
+			continue
+		}
+		v := values[key]
+		if !s.Required && exportIsDefault(s, v) {
+			continue
+		}
+		if res, ok := s.Elem.(*schema.Resource); ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet) {
+			for i, item := range exportItems(v) {
+				block := body.AppendNewBlock(key, nil)
+				vars = append(vars, exportWriteBody(block.Body(), res.Schema, item.(map[string]any), refs, fmt.Sprintf("%s.%s[%d]", address, key, i))...)
+			}
+			continue
+		}
+		// Secrets are never written to the configuration, they have to be passed as variables
+		if s.Sensitive {
+			name := strings.Trim(exportInvalidName.ReplaceAllString(strings.TrimPrefix(address, "authentik_")+"_"+key, "_"), "_")
+			body.SetAttributeTraversal(key, hcl.Traversal{
+				hcl.TraverseRoot{Name: "var"},
+				hcl.TraverseAttr{Name: name},
+			})
+			vars = append(vars, exportVariable{
+				name:        name,
+				description: fmt.Sprintf("Value of `%s` of `%s`", key, address),
+			})
+			continue
+		}
+		body.SetAttributeRaw(key, exportTokens(key, s, v, refs))
+	}
+	return vars
+}
+
+func exportItems(v any) []any {
+	switch vv := v.(type) {
+	case *schema.Set:
+		return vv.List()
+	case []any:
+		return vv
+	}
+	return []any{}
+}
+
+// exportTokens Get the tokens for a single value, replacing IDs of exported objects with references
+func exportTokens(key string, s *schema.Schema, v any, refs exportReferences) hclwrite.Tokens {
+	switch vv := v.(type) {
+	case string:
+		if ref, ok := refs[vv]; ok && exportUUID.MatchString(vv) {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		return hclwrite.TokensForValue(cty.StringVal(vv))
+	case int:
+		if ref, ok := refs[exportProviderKey(strconv.Itoa(vv))]; ok && slices.Contains(exportProviderAttributes, key) {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(vv)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(vv))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(vv))
+	case *schema.Set, []any:
+		elem, _ := s.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		items := []hclwrite.Tokens{}
+		for _, item := range exportItems(vv) {
+			items = append(items, exportTokens(key, elem, item, refs))
+		}
+		return hclwrite.TokensForTuple(items)
+	case map[string]any:
+		elem, _ := s.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		keys := make([]string, 0, len(vv))
+		for k := range vv {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		attrs := []hclwrite.ObjectAttrTokens{}
+		for _, k := range keys {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+				Value: exportTokens(key, elem, vv[k], refs),
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "default-authentication-flow", exportName("default-authentication-flow", used))
+	assert.Equal(t, "my_app", exportName("My App!", used))
+	assert.Equal(t, "my_app_2", exportName("my app", used))
+	assert.Equal(t, "_2fa", exportName("2FA", used))
+	assert.Equal(t, "object", exportName("???", used))
+}
+
+func TestExportModelResourceType(t *testing.T) {
+	assert.Equal(t, "authentik_stage_identification", exportModelResourceType("authentik_stages_identification.identificationstage"))
+	assert.Equal(t, "authentik_stage_mutual_tls", exportModelResourceType("authentik_stages_mtls.mutualtlsstage"))
+	assert.Equal(t, "authentik_policy_expression", exportModelResourceType("authentik_policies_expression.expressionpolicy"))
+	assert.Equal(t, "authentik_provider_oauth2", exportModelResourceType("authentik_providers_oauth2.oauth2provider"))
+	assert.Equal(t, "", exportModelResourceType("authentik_core.user"))
+}
+
+func TestExportWriteResource(t *testing.T) {
+	flowRes := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"uuid": {Type: schema.TypeString, Computed: true},
+			"slug": {Type: schema.TypeString, Required: true},
+		},
+	}
+	flow := &exportObject{resourceType: "authentik_flow", id: "default-authentication-flow", name: "auth"}
+	flow.data = flowRes.TestResourceData()
+	flow.data.SetId(flow.id)
+	assert.NoError(t, flow.data.Set("uuid", "0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11"))
+	assert.NoError(t, flow.data.Set("slug", flow.id))
+
+	appRes := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":                  {Type: schema.TypeString, Required: true},
+			"authentication_flow":   {Type: schema.TypeString, Optional: true},
+			"protocol_provider":     {Type: schema.TypeInt, Optional: true},
+			"open_in_new_tab":       {Type: schema.TypeBool, Optional: true, Default: false},
+			"policy_engine_mode":    {Type: schema.TypeString, Optional: true, Default: "any"},
+			"backchannel_providers": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+			"meta": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {Type: schema.TypeString, Required: true},
+					},
+				},
+			},
+		},
+	}
+	app := &exportObject{resourceType: "authentik_application", id: "app", name: "app"}
+	app.data = appRes.TestResourceData()
+	app.data.SetId(app.id)
+	assert.NoError(t, app.data.Set("name", "App"))
+	assert.NoError(t, app.data.Set("authentication_flow", "0b6f4a5e-93f2-4a4b-9b3f-6d7f3f8a2e11"))
+	assert.NoError(t, app.data.Set("protocol_provider", 3))
+	assert.NoError(t, app.data.Set("backchannel_providers", []int{3, 4}))
+	assert.NoError(t, app.data.Set("policy_engine_mode", "any"))
+	assert.NoError(t, app.data.Set("meta", []map[string]any{{"key": "value"}}))
+
+	refs := exportReferences{}
+	refs.add(flow, flowRes)
+	refs.add(&exportObject{resourceType: "authentik_provider_oauth2", id: "3", name: "oauth"}, &schema.Resource{})
+
+	f := hclwrite.NewEmptyFile()
+	exportWriteResource(f.Body(), app, appRes, refs)
+	exportWriteImport(f.Body(), app)
+	assert.Equal(t, `resource "authentik_application" "app" {
+  authentication_flow   = authentik_flow.auth.uuid
+  backchannel_providers = [authentik_provider_oauth2.oauth.id, 4]
+  meta {
+    key = "value"
+  }
+  name              = "App"
+  protocol_provider = authentik_provider_oauth2.oauth.id
+}
+import {
+  to = authentik_application.app
+  id = "app"
+}
+`, string(hclwrite.Format(f.Bytes())))
+}
+
+func TestExportWriteResource_Sensitive(t *testing.T) {
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":          {Type: schema.TypeString, Required: true},
+			"client_secret": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		},
+	}
+	o := &exportObject{resourceType: "authentik_provider_oauth2", id: "3", name: "oauth"}
+	o.data = res.TestResourceData()
+	o.data.SetId(o.id)
+	assert.NoError(t, o.data.Set("name", "OAuth"))
+	assert.NoError(t, o.data.Set("client_secret", "very-secret"))
+
+	f := hclwrite.NewEmptyFile()
+	vars := exportWriteResource(f.Body(), o, res, exportReferences{})
+	exportWriteVariables(f.Body(), vars)
+	assert.NotContains(t, string(f.Bytes()), "very-secret")
+	assert.Equal(t, `resource "authentik_provider_oauth2" "oauth" {
+  client_secret = var.provider_oauth2_oauth_client_secret
+  name          = "OAuth"
+}
+variable "provider_oauth2_oauth_client_secret" {
+  description = "Value of `+"`client_secret`"+` of `+"`authentik_provider_oauth2.oauth`"+`"
+  sensitive   = true
+}
+`, string(hclwrite.Format(f.Bytes())))
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+// importLookup Resolve the value of a natural key to the ID of an object
+type importLookup func(ctx context.Context, c *APIClient, value string) (string, error)
+
+// importByNaturalKey Importer which accepts either the ID of an object or one of the given
+// natural keys in the form of `key=value`, for example `username=akadmin`
+func importByNaturalKey(lookups map[string]importLookup) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+		key, value, ok := strings.Cut(d.Id(), "=")
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+		lookup, ok := lookups[key]
+		if !ok {
+			keys := make([]string, 0, len(lookups))
+			for k := range lookups {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			return nil, fmt.Errorf("unsupported import key '%s', expected the ID or one of: %s", key, strings.Join(keys, ", "))
+		}
+		id, err := lookup(ctx, m.(*APIClient), value)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+var (
+	importUserKeys        = map[string]importLookup{"username": importUserByUsername}
+	importGroupKeys       = map[string]importLookup{"name": importGroupByName}
+	importFlowKeys        = map[string]importLookup{"slug": importSlug}
+	importApplicationKeys = map[string]importLookup{"slug": importSlug, "name": importApplicationByName}
+	importSourceKeys      = map[string]importLookup{"slug": importSlug, "name": importSourceByName}
+	importStageKeys       = map[string]importLookup{"name": importStageByName}
+	importPromptFieldKeys = map[string]importLookup{"name": importPromptFieldByName}
+	importPolicyKeys      = map[string]importLookup{"name": importPolicyByName}
+	importProviderKeys    = map[string]importLookup{"name": importProviderByName}
+)
+
+// importSingle Get the ID of the only object matching a natural key
+func importSingle(kind string, key string, value string, ids []string) (string, error) {
+	if len(ids) < 1 {
+		return "", fmt.Errorf("no %s found with %s '%s'", kind, key, value)
+	}
+	if len(ids) > 1 {
+		return "", fmt.Errorf("%s '%s' matches more than one %s", key, value, kind)
+	}
+	return ids[0], nil
+}
+
+// importSlug Objects which already use their slug as ID
+func importSlug(ctx context.Context, c *APIClient, value string) (string, error) {
+	if value == "" {
+		return "", errors.New("slug must not be empty")
+	}
+	return value, nil
+}
+
+func importUserByUsername(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.CoreAPI.CoreUsersList(ctx).Username(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, u := range res.Results {
+		ids = append(ids, strconv.Itoa(int(u.Pk)))
+	}
+	return importSingle("user", "username", value, ids)
+}
+
+func importGroupByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.CoreAPI.CoreGroupsList(ctx).IncludeUsers(false).Name(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, g := range res.Results {
+		ids = append(ids, g.Pk)
+	}
+	return importSingle("group", "name", value, ids)
+}
+
+func importApplicationByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.CoreAPI.CoreApplicationsList(ctx).Name(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, a := range res.Results {
+		ids = append(ids, a.Slug)
+	}
+	return importSingle("application", "name", value, ids)
+}
+
+func importSourceByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.SourcesAPI.SourcesAllList(ctx).Name(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, s := range res.Results {
+		ids = append(ids, s.Slug)
+	}
+	return importSingle("source", "name", value, ids)
+}
+
+func importStageByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.StagesAPI.StagesAllList(ctx).Name(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, s := range res.Results {
+		ids = append(ids, s.Pk)
+	}
+	return importSingle("stage", "name", value, ids)
+}
+
+func importPromptFieldByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	res, _, err := c.client.StagesAPI.StagesPromptPromptsList(ctx).Name(value).Execute()
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, p := range res.Results {
+		ids = append(ids, p.Pk)
+	}
+	return importSingle("prompt field", "name", value, ids)
+}
+
+func importPolicyByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	// Policies can only be searched, which also matches partial names
+	res, _, err := helpers.Paginator(ctx, c.client.PoliciesAPI.PoliciesAllList(ctx).Search(value), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, p := range res {
+		if p.Name == value {
+			ids = append(ids, p.Pk)
+		}
+	}
+	return importSingle("policy", "name", value, ids)
+}
+
+func importProviderByName(ctx context.Context, c *APIClient, value string) (string, error) {
+	// Providers can only be searched, which also matches partial names
+	res, _, err := helpers.Paginator(ctx, c.client.ProvidersAPI.ProvidersAllList(ctx).Search(value), helpers.PaginatorOptions{PageSize: c.pageSize})
+	if err != nil {
+		return "", err
+	}
+	ids := []string{}
+	for _, p := range res {
+		if p.Name == value {
+			ids = append(ids, strconv.Itoa(int(p.Pk)))
+		}
+	}
+	return importSingle("provider", "name", value, ids)
+}
+
+// importFlowStageBinding Import a flow stage binding by its ID or by `flow-slug/stage-name/order`
+func importFlowStageBinding(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) == 1 {
+		return []*schema.ResourceData{d}, nil
+	}
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid import ID '%s', expected the ID or `flow-slug/stage-name/order`", d.Id())
+	}
+	c := m.(*APIClient)
+	slug, stageName := parts[0], strings.Join(parts[1:len(parts)-1], "/")
+	order, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid order '%s': %w", parts[len(parts)-1], err)
+	}
+	flow, _, err := c.client.FlowsAPI.FlowsInstancesRetrieve(ctx, slug).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flow '%s': %w", slug, err)
+	}
+	stage, err := importStageByName(ctx, c, stageName)
+	if err != nil {
+		return nil, err
+	}
+	bindings, _, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsBindingsList(ctx).Target(flow.Pk), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, b := range bindings {
+		if b.Stage == stage && int(b.Order) == order {
+			ids = append(ids, b.Pk)
+		}
+	}
+	id, err := importSingle("binding", "import ID", d.Id(), ids)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// importPolicyBinding Import a policy binding by its ID or by `target-uuid/policy-name`
+func importPolicyBinding(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	target, policyName, ok := strings.Cut(d.Id(), "/")
+	if !ok {
+		return []*schema.ResourceData{d}, nil
+	}
+	c := m.(*APIClient)
+	policy, err := importPolicyByName(ctx, c, policyName)
+	if err != nil {
+		return nil, err
+	}
+	bindings, _, err := helpers.Paginator(ctx, c.client.PoliciesAPI.PoliciesBindingsList(ctx).Target(target), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, b := range bindings {
+		if b.GetPolicy() == policy {
+			ids = append(ids, b.Pk)
+		}
+	}
+	id, err := importSingle("binding", "import ID", d.Id(), ids)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// importRBACRoleObjectPermission Import a permission assigned to a role by `role/permission` for global
+// permissions or `role/permission/model/object_id` for object permissions. As the permission ID alone
+// isn't enough to read the assignment, the plain ID can't be imported.
+func importRBACRoleObjectPermission(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 && len(parts) != 4 {
+		return nil, fmt.Errorf("invalid import ID '%s', expected `role/permission` or `role/permission/model/object_id`", d.Id())
+	}
+	c := m.(*APIClient)
+	role, permission := parts[0], parts[1]
+	helpers.SetWrapper(d, "role", role)
+	helpers.SetWrapper(d, "permission", permission)
+
+	ids := []string{}
+	if len(parts) == 4 {
+		model, objectID := parts[2], parts[3]
+		helpers.SetWrapper(d, "model", model)
+		helpers.SetWrapper(d, "object_id", objectID)
+		perms, _, err := helpers.Paginator(ctx, c.client.RbacAPI.RbacPermissionsRolesList(ctx).Uuid(role), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, perm := range perms {
+			if fmt.Sprintf("%s.%s", perm.AppLabel, perm.Codename) == permission && perm.ObjectPk == objectID {
+				ids = append(ids, strconv.Itoa(int(perm.Id)))
+			}
+		}
+	} else {
+		perms, _, err := helpers.Paginator(ctx, c.client.RbacAPI.RbacPermissionsList(ctx).Role(role), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, perm := range perms {
+			if fmt.Sprintf("%s.%s", perm.AppLabel, perm.Codename) == permission {
+				ids = append(ids, strconv.Itoa(int(perm.Id)))
+			}
+		}
+	}
+	id, err := importSingle("permission", "import ID", d.Id(), ids)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
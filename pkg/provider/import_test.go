@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportByNaturalKey(t *testing.T) {
+	importer := importByNaturalKey(map[string]importLookup{
+		"slug": importSlug,
+		"name": func(ctx context.Context, c *APIClient, value string) (string, error) {
+			return "id-of-" + value, nil
+		},
+	})
+	res := &schema.Resource{Schema: map[string]*schema.Schema{}}
+
+	for id, expected := range map[string]string{
+		"1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71": "1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71",
+		"slug=my-app":                          "my-app",
+		"name=My App":                          "id-of-My App",
+	} {
+		d := res.TestResourceData()
+		d.SetId(id)
+		out, err := importer(t.Context(), d, &APIClient{})
+		assert.NoError(t, err)
+		assert.Len(t, out, 1)
+		assert.Equal(t, expected, out[0].Id())
+	}
+
+	d := res.TestResourceData()
+	d.SetId("username=akadmin")
+	_, err := importer(t.Context(), d, &APIClient{})
+	assert.EqualError(t, err, "unsupported import key 'username', expected the ID or one of: name, slug")
+
+	d = res.TestResourceData()
+	d.SetId("slug=")
+	_, err = importer(t.Context(), d, &APIClient{})
+	assert.Error(t, err)
+}
+
+func TestImportSingle(t *testing.T) {
+	id, err := importSingle("group", "name", "admins", []string{"a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", id)
+	_, err = importSingle("group", "name", "admins", []string{})
+	assert.EqualError(t, err, "no group found with name 'admins'")
+	_, err = importSingle("group", "name", "admins", []string{"a", "b"})
+	assert.EqualError(t, err, "name 'admins' matches more than one group")
+}
+
+func TestImportCompositeInvalid(t *testing.T) {
+	res := &schema.Resource{Schema: map[string]*schema.Schema{}}
+
+	d := res.TestResourceData()
+	d.SetId("1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71")
+	out, err := importFlowStageBinding(t.Context(), d, &APIClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71", out[0].Id())
+
+	d.SetId("default-authentication-flow/stage")
+	_, err = importFlowStageBinding(t.Context(), d, &APIClient{})
+	assert.EqualError(t, err, "invalid import ID 'default-authentication-flow/stage', expected the ID or `flow-slug/stage-name/order`")
+
+	d.SetId("default-authentication-flow/stage/first")
+	_, err = importFlowStageBinding(t.Context(), d, &APIClient{})
+	assert.ErrorContains(t, err, "invalid order 'first'")
+
+	d = res.TestResourceData()
+	d.SetId("1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71")
+	out, err = importPolicyBinding(t.Context(), d, &APIClient{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1f0c3a8e-8a1f-4b9e-9c1d-5f3d0f6a2b71", out[0].Id())
+
+	d = resourceRBACRoleObjectPermission().TestResourceData()
+	d.SetId("42")
+	_, err = importRBACRoleObjectPermission(t.Context(), d, &APIClient{})
+	assert.EqualError(t, err, "invalid import ID '42', expected `role/permission` or `role/permission/model/object_id`")
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+// lockoutState Users relevant to decide whether a change would lock everyone out of authentik
+type lockoutState struct {
+	// PK of the user the provider authenticates as
+	me int32
+	// PKs of all active superusers
+	superusers []int32
+}
+
+// lockoutCurrentState Get the user the provider authenticates as and all active superusers.
+// Returns nil when lockout checks are disabled.
+func (c *APIClient) lockoutCurrentState(ctx context.Context, d *schema.ResourceData) (*lockoutState, diag.Diagnostics) {
+	if c.allowLockout {
+		return nil, nil
+	}
+	me, hr, err := c.client.CoreAPI.CoreUsersMeRetrieve(ctx).Execute()
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	users, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreUsersList(ctx).IsSuperuser(true).IsActive(true), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	state := &lockoutState{me: me.User.Pk}
+	for _, u := range users {
+		state.superusers = append(state.superusers, u.Pk)
+	}
+	return state, nil
+}
+
+// isSuperuser Check if a user is currently an active superuser
+func (s *lockoutState) isSuperuser(pk int32) bool {
+	return slices.Contains(s.superusers, pk)
+}
+
+// check Returns an error if the users in `affected` losing their access would leave no active
+// superuser, or would remove the access of the user the provider authenticates as
+func (s *lockoutState) check(action string, affected []int32) diag.Diagnostics {
+	if s == nil || len(affected) < 1 {
+		return nil
+	}
+	override := "Set `allow_lockout = true` on the provider to apply this change anyway."
+	if slices.Contains(affected, s.me) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Change would lock out the provider",
+				Detail:   fmt.Sprintf("%s would remove the access of the user the provider authenticates as. %s", action, override),
+			},
+		}
+	}
+	// Only relevant when at least one active superuser loses their access
+	if !slices.ContainsFunc(affected, s.isSuperuser) {
+		return nil
+	}
+	for _, pk := range s.superusers {
+		if !slices.Contains(affected, pk) {
+			return nil
+		}
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Change would remove the last superuser",
+			Detail:   fmt.Sprintf("%s would leave authentik without any active superuser. %s", action, override),
+		},
+	}
+}
+
+// superuserGroups Get the members of all groups which grant superuser permissions, by group UUID
+func (c *APIClient) superuserGroups(ctx context.Context, d *schema.ResourceData) (map[string][]int32, diag.Diagnostics) {
+	groups, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreGroupsList(ctx).IsSuperuser(true).IncludeUsers(true), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	members := make(map[string][]int32, len(groups))
+	for _, g := range groups {
+		members[g.Pk] = g.Users
+	}
+	return members, nil
+}
+
+// checkGroupMembersRemoval Prevent removing the user the provider authenticates as or the last active
+// superusers from a group which grants superuser permissions
+func (c *APIClient) checkGroupMembersRemoval(ctx context.Context, d *schema.ResourceData, group string, removed []int32) diag.Diagnostics {
+	state, diags := c.lockoutCurrentState(ctx, d)
+	if diags != nil || state == nil {
+		return diags
+	}
+	groups, diags := c.superuserGroups(ctx, d)
+	if diags != nil {
+		return diags
+	}
+	if _, ok := groups[group]; !ok {
+		return nil
+	}
+	affected := []int32{}
+	for _, pk := range removed {
+		// Members of other superuser groups keep their permissions
+		other := false
+		for g, users := range groups {
+			if g != group && slices.Contains(users, pk) {
+				other = true
+				break
+			}
+		}
+		if !other {
+			affected = append(affected, pk)
+		}
+	}
+	return state.check(fmt.Sprintf("Removing users from group '%s'", group), affected)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutStateCheck(t *testing.T) {
+	state := &lockoutState{me: 1, superusers: []int32{1, 2}}
+
+	assert.Nil(t, state.check("Deleting user 'test'", []int32{}))
+	assert.Nil(t, state.check("Deleting user 'test'", []int32{2}))
+	assert.Nil(t, state.check("Deleting user 'test'", []int32{3}))
+
+	diags := state.check("Deleting user 'akadmin'", []int32{1})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Change would lock out the provider", diags[0].Summary)
+
+	state = &lockoutState{me: 3, superusers: []int32{1, 2}}
+	diags = state.check("Deleting group 'admins'", []int32{1, 2})
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Change would remove the last superuser", diags[0].Summary)
+
+	var disabled *lockoutState
+	assert.Nil(t, disabled.check("Deleting group 'admins'", []int32{1, 2}))
+	assert.True(t, state.isSuperuser(1))
+	assert.False(t, state.isSuperuser(3))
+
+	// Users which aren't superusers can be removed even if there's no active superuser
+	state = &lockoutState{me: 1}
+	assert.Nil(t, state.check("Deleting user 'test'", []int32{3}))
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Kinds of objects which can be listed in the provider's `protected_objects`
+var protectedObjectKinds = []string{"application", "brand", "flow", "group", "provider", "user"}
+
+// deletionProtectionSchema Attribute to prevent an object from being deleted
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Prevent the object from being deleted. Has to be disabled and applied before the object can be destroyed.",
+	}
+}
+
+// checkDeletionProtection Returns an error if the object is protected against deletion, either by
+// its `deletion_protection` attribute or by being listed in the provider's `protected_objects` as `kind:key`
+func (c *APIClient) checkDeletionProtection(d *schema.ResourceData, kind string, key string) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Object is protected against deletion",
+				Detail:   fmt.Sprintf("The %s '%s' has `deletion_protection` enabled. Set `deletion_protection = false` and apply the change before destroying it.", kind, key),
+			},
+		}
+	}
+	if slices.Contains(c.protectedObjects, fmt.Sprintf("%s:%s", kind, key)) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Object is protected against deletion",
+				Detail:   fmt.Sprintf("The %s '%s' is listed in the provider's `protected_objects` and can't be deleted.", kind, key),
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	res := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"slug":                {Type: schema.TypeString, Required: true},
+			"deletion_protection": deletionProtectionSchema(),
+		},
+	}
+	c := &APIClient{protectedObjects: []string{"flow:default-authentication-flow"}}
+
+	d := res.TestResourceData()
+	assert.NoError(t, d.Set("slug", "my-flow"))
+	assert.Nil(t, c.checkDeletionProtection(d, "flow", "my-flow"))
+
+	assert.NoError(t, d.Set("deletion_protection", true))
+	diags := c.checkDeletionProtection(d, "flow", "my-flow")
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "`deletion_protection`")
+
+	d = res.TestResourceData()
+	diags = c.checkDeletionProtection(d, "flow", "default-authentication-flow")
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "`protected_objects`")
+
+	// Objects of other kinds with the same key aren't protected
+	assert.Nil(t, c.checkDeletionProtection(d, "application", "default-authentication-flow"))
+}
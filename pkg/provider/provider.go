@@ -4,16 +4,23 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/getsentry/sentry-go"
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	api "goauthentik.io/api/v3"
 	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
 )
@@ -53,12 +60,108 @@ func Provider(version string, testing bool) *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_INSECURE", false),
 				Description: "Whether to skip TLS verification, can optionally be passed as `AUTHENTIK_INSECURE` environmental variable",
 			},
-			"token": {
+			"ca_cert_file": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_TOKEN", nil),
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CA_CERT_FILE", nil),
+				Description: "Path to a file with PEM encoded CA certificates trusted in addition to the system's CA certificates, can optionally be passed as `AUTHENTIK_CA_CERT_FILE` environmental variable",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CA certificates trusted in addition to the system's CA certificates",
+			},
+			"client_cert": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CLIENT_CERT", nil),
+				Description: "PEM encoded client certificate used for mutual TLS, can optionally be passed as `AUTHENTIK_CLIENT_CERT` environmental variable",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The authentik API token, can optionally be passed as `AUTHENTIK_TOKEN` environmental variable",
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_CLIENT_KEY", nil),
+				Description: "PEM encoded private key of `client_cert`, can optionally be passed as `AUTHENTIK_CLIENT_KEY` environmental variable",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_TLS_SERVER_NAME", nil),
+				Description: "Server name used to verify the certificate of authentik, if it differs from the host in `url`. Can optionally be passed as `AUTHENTIK_TLS_SERVER_NAME` environmental variable",
+			},
+			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("AUTHENTIK_TOKEN", nil),
+				Sensitive:     true,
+				ConflictsWith: []string{"token_file", "token_command", "auth"},
+				Description:   "The authentik API token, can optionally be passed as `AUTHENTIK_TOKEN` environmental variable. Required unless `auth`, `token_file` or `token_command` is configured.",
+			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("AUTHENTIK_TOKEN_FILE", nil),
+				ConflictsWith: []string{"token", "token_command", "auth"},
+				Description:   "Path to a file containing the authentik API token. The file is read again when authentik rejects the token, so rotated tokens take effect without restarting. Can optionally be passed as `AUTHENTIK_TOKEN_FILE` environmental variable",
+			},
+			"token_command": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("AUTHENTIK_TOKEN_COMMAND", nil),
+				ConflictsWith: []string{"token", "token_file", "auth"},
+				Description:   "Command run in the system shell which prints the authentik API token, for example `op read op://vault/authentik/token`. The command is run again when authentik rejects the token. Can optionally be passed as `AUTHENTIK_TOKEN_COMMAND` environmental variable",
+			},
+			"auth": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"token", "token_file", "token_command"},
+				Description:   "Authenticate using short-lived tokens issued by an authentik OAuth2 provider via the `client_credentials` grant, instead of a static `token`. Tokens are refreshed automatically.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Client ID of the OAuth2 provider",
+						},
+						"token_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Token endpoint, defaults to `/application/o/token/` on the authentik instance configured in `url`",
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: fmt.Sprintf("Scopes to request, defaults to `%s` which is required to access the authentik API", DefaultOAuth2Scope),
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Username of the service account to authenticate as, used together with `password`",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "App password token of the service account",
+						},
+						"jwt": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "JWT used as client assertion, issued by a source or provider configured for JWT federation on the OAuth2 provider",
+						},
+						"jwt_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a file containing the JWT used as client assertion. The file is read again whenever a new token is requested, to support short-lived JWTs such as Kubernetes service account tokens",
+						},
+					},
+				},
 			},
 			"headers": {
 				Type: schema.TypeMap,
@@ -69,26 +172,75 @@ func Provider(version string, testing bool) *schema.Provider {
 				Sensitive:   true,
 				Description: "Optional HTTP headers sent with every request",
 			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("AUTHENTIK_MAX_RETRIES", DefaultMaxRetries),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Maximum number of retries for requests that failed because authentik was temporarily unavailable or rate-limited the request, and for reading objects which aren't visible yet right after they were created. Set to `0` to disable retries. Can optionally be passed as `AUTHENTIK_MAX_RETRIES` environmental variable",
+			},
+			"retry_max_wait": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_RETRY_MAX_WAIT", DefaultRetryMaxWait),
+				Description: "Maximum time in seconds to wait between retries, including waits requested by the server via the `Retry-After` header. Can optionally be passed as `AUTHENTIK_RETRY_MAX_WAIT` environmental variable",
+			},
+			"page_size": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("AUTHENTIK_PAGE_SIZE", helpers.DefaultPageSize),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Number of objects fetched per request when listing objects. Should not exceed the maximum page size configured in authentik. Can optionally be passed as `AUTHENTIK_PAGE_SIZE` environmental variable",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_ADOPT_EXISTING", false),
+				Description: "Adopt existing flows, groups and users with the same slug, name or username instead of failing to create them. Can be overridden per resource and optionally be passed as `AUTHENTIK_ADOPT_EXISTING` environmental variable",
+			},
+			"allow_lockout": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AUTHENTIK_ALLOW_LOCKOUT", false),
+				Description: "Allow changes which would remove the last active superuser or the access of the user the provider authenticates as. The check runs when changes are applied, not during plan. Can optionally be passed as `AUTHENTIK_ALLOW_LOCKOUT` environmental variable",
+			},
+			"protected_objects": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+						regexp.MustCompile(`^(`+strings.Join(protectedObjectKinds, "|")+`):.+$`),
+						"expected `kind:key`, for example `user:akadmin`",
+					)),
+				},
+				Description: "Objects which can't be deleted, regardless of their `deletion_protection`, in the form of `kind:key`. Supported are `application:<slug>`, `brand:<domain>`, `flow:<slug>`, `group:<name>`, `provider:<name>` and `user:<username>`, for example `user:akadmin`",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"authentik_application_entitlement":                    tr(resourceApplicationEntitlement),
+			"authentik_application_entitlement":                    tr(minVersion(resourceApplicationEntitlement, "2024.12")),
 			"authentik_application":                                tr(resourceApplication),
 			"authentik_blueprint":                                  tr(resourceBlueprintInstance),
 			"authentik_brand":                                      tr(resourceBrand),
 			"authentik_certificate_key_pair":                       tr(resourceCertificateKeyPair),
-			"authentik_endpoints_connector_agent":                  tr(resourceEndpointsConnectorAgent),
-			"authentik_endpoints_connector_agent_enrollment_token": tr(resourceEndpointsEnrollmentToken),
-			"authentik_endpoints_device_access_group":              tr(resourceEndpointsDeviceAccessGroup),
-			"authentik_endpoints_google_chrome_connector":          tr(resourceEndpointsGoogleChromeConnector),
+			"authentik_endpoints_connector_agent":                  tr(minVersion(resourceEndpointsConnectorAgent, "2025.10")),
+			"authentik_endpoints_connector_agent_enrollment_token": tr(minVersion(resourceEndpointsEnrollmentToken, "2025.10")),
+			"authentik_endpoints_device_access_group":              tr(minVersion(resourceEndpointsDeviceAccessGroup, "2025.10")),
+			"authentik_endpoints_google_chrome_connector":          tr(minVersion(resourceEndpointsGoogleChromeConnector, "2025.10")),
 			"authentik_enterprise_license":                         tr(resourceEnterpriseLicense),
 			"authentik_event_rule":                                 tr(resourceEventRule),
 			"authentik_event_transport":                            tr(resourceEventTransport),
 			"authentik_flow_stage_binding":                         tr(resourceFlowStageBinding),
+			"authentik_flow_stages":                                tr(resourceFlowStages),
 			"authentik_flow":                                       tr(resourceFlow),
 			"authentik_group":                                      tr(resourceGroup),
+			"authentik_group_members":                              tr(resourceGroupMembers),
+			"authentik_group_membership":                           tr(resourceGroupMembership),
+			"authentik_managed_object":                             tr(resourceManagedObject),
 			"authentik_outpost":                                    tr(resourceOutpost),
 			"authentik_outpost_provider_attachment":                tr(resourceOutpostProviderAttachment),
 			"authentik_policy_binding":                             tr(resourcePolicyBinding),
+			"authentik_policy_bindings":                            tr(resourcePolicyBindings),
 			"authentik_policy_dummy":                               tr(resourcePolicyDummy),
 			"authentik_policy_event_matcher":                       tr(resourcePolicyEventMatcher),
 			"authentik_policy_expiry":                              tr(resourcePolicyExpiry),
@@ -110,37 +262,39 @@ func Provider(version string, testing bool) *schema.Provider {
 			"authentik_property_mapping_source_plex":               tr(resourcePropertyMappingSourcePlex),
 			"authentik_property_mapping_source_saml":               tr(resourcePropertyMappingSourceSAML),
 			"authentik_property_mapping_source_scim":               tr(resourcePropertyMappingSourceSCIM),
-			"authentik_property_mapping_source_kerberos":           tr(resourcePropertyMappingSourceKerberos),
+			"authentik_property_mapping_source_kerberos":           tr(minVersion(resourcePropertyMappingSourceKerberos, "2024.10")),
 			"authentik_provider_google_workspace":                  tr(resourceProviderGoogleWorkspace),
 			"authentik_provider_ldap":                              tr(resourceProviderLDAP),
 			"authentik_provider_microsoft_entra":                   tr(resourceProviderMicrosoftEntra),
-			"authentik_provider_oauth2":                            tr(resourceProviderOAuth2),
+			"authentik_provider_oauth2":                            tr(attributeVersions(resourceProviderOAuth2, resourceProviderOAuth2AttributeVersions)),
 			"authentik_provider_proxy":                             tr(resourceProviderProxy),
 			"authentik_provider_rac":                               tr(resourceProviderRAC),
 			"authentik_provider_radius":                            tr(resourceProviderRadius),
 			"authentik_provider_saml":                              tr(resourceProviderSAML),
 			"authentik_provider_scim":                              tr(resourceProviderSCIM),
-			"authentik_provider_ssf":                               tr(resourceProviderSSF),
-			"authentik_provider_ws_federation":                     tr(resourceProviderWSFederation),
+			"authentik_provider_ssf":                               tr(minVersion(resourceProviderSSF, "2024.12")),
+			"authentik_provider_ws_federation":                     tr(minVersion(resourceProviderWSFederation, "2025.12")),
 			"authentik_rac_endpoint":                               tr(resourceRACEndpoint),
 			"authentik_rbac_initial_permissions":                   tr(resourceRBACInitialPermissions),
 			"authentik_rbac_permission_role":                       tr(resourceRBACRoleObjectPermission),
 			// TODO: Remove in 2026.2 or later
 			"authentik_rbac_permission_user":              tr(helpers.MarkDeprecated(resourceRBACUserObjectPermission, "authentik_rbac_permission_role")),
 			"authentik_rbac_role":                         tr(resourceRBACRole),
+			"authentik_rbac_role_assignment":              tr(resourceRBACRoleAssignment),
+			"authentik_service_account":                   tr(resourceServiceAccount),
 			"authentik_service_connection_docker":         tr(resourceServiceConnectionDocker),
 			"authentik_service_connection_kubernetes":     tr(resourceServiceConnectionKubernetes),
-			"authentik_source_kerberos":                   tr(resourceSourceKerberos),
+			"authentik_source_kerberos":                   tr(minVersion(resourceSourceKerberos, "2024.10")),
 			"authentik_source_ldap":                       tr(resourceSourceLDAP),
 			"authentik_source_oauth":                      tr(resourceSourceOAuth),
 			"authentik_source_plex":                       tr(resourceSourcePlex),
 			"authentik_source_saml":                       tr(resourceSourceSAML),
 			"authentik_source_scim":                       tr(resourceSourceSCIM),
-			"authentik_source_telegram":                   tr(resourceSourceTelegram),
-			"authentik_stage_account_lockdown":            tr(resourceStageAccountLockdown),
+			"authentik_source_telegram":                   tr(minVersion(resourceSourceTelegram, "2025.6")),
+			"authentik_stage_account_lockdown":            tr(minVersion(resourceStageAccountLockdown, "2026.2")),
 			"authentik_stage_authenticator_duo":           tr(resourceStageAuthenticatorDuo),
 			"authentik_stage_authenticator_email":         tr(resourceStageAuthenticatorEmail),
-			"authentik_stage_authenticator_endpoint_gdtc": tr(resourceStageAuthenticatorEndpointGDTC),
+			"authentik_stage_authenticator_endpoint_gdtc": tr(minVersion(resourceStageAuthenticatorEndpointGDTC, "2024.12")),
 			"authentik_stage_authenticator_sms":           tr(resourceStageAuthenticatorSms),
 			"authentik_stage_authenticator_static":        tr(resourceStageAuthenticatorStatic),
 			"authentik_stage_authenticator_totp":          tr(resourceStageAuthenticatorTOTP),
@@ -151,7 +305,7 @@ func Provider(version string, testing bool) *schema.Provider {
 			"authentik_stage_deny":                        tr(resourceStageDeny),
 			"authentik_stage_dummy":                       tr(resourceStageDummy),
 			"authentik_stage_email":                       tr(resourceStageEmail),
-			"authentik_stage_endpoints":                   tr(resourceStageEndpoints),
+			"authentik_stage_endpoints":                   tr(minVersion(resourceStageEndpoints, "2025.10")),
 			"authentik_stage_identification":              tr(resourceStageIdentification),
 			"authentik_stage_invitation":                  tr(resourceStageInvitation),
 			"authentik_stage_mutual_tls":                  tr(resourceStageMutualTLS),
@@ -165,11 +319,12 @@ func Provider(version string, testing bool) *schema.Provider {
 			"authentik_stage_user_logout":                 tr(resourceStageUserLogout),
 			"authentik_stage_user_write":                  tr(resourceStageUserWrite),
 			"authentik_system_settings":                   tr(resourceSystemSettings),
-			"authentik_task_schedule":                     tr(resourceTaskSchedule),
+			"authentik_task_schedule":                     tr(minVersion(resourceTaskSchedule, "2025.8")),
 			"authentik_token":                             tr(resourceToken),
 			"authentik_user":                              tr(resourceUser),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"authentik_blueprint_export":                 td(dataSourceBlueprintExport),
 			"authentik_brand":                            td(dataSourceBrand),
 			"authentik_certificate_key_pair":             td(dataSourceCertificateKeyPair),
 			"authentik_flow":                             td(dataSourceFlow),
@@ -199,13 +354,26 @@ func Provider(version string, testing bool) *schema.Provider {
 // APIClient Hold the API Client and any relevant configuration
 type APIClient struct {
 	client *api.APIClient
+	// Page size used when listing objects
+	pageSize int
+	// Version of the authentik server, nil when it couldn't be determined
+	serverVersion *goversion.Version
+	// Adopt existing objects when creating them conflicts
+	adoptExisting bool
+	// Slugs, names, usernames or domains of objects which can't be deleted
+	protectedObjects []string
+	// Skip checks preventing the removal of the last superuser or the provider's own user
+	allowLockout bool
+	// Locks serializing role assignments per user or group
+	roleTargetLocks sync.Map
 }
 
 func providerConfigure(version string, testing bool) schema.ConfigureContextFunc {
 	return func(c context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 		apiURL := d.Get("url").(string)
 		token := d.Get("token").(string)
-		insecure := d.Get("insecure").(bool)
+		maxRetries := d.Get("max_retries").(int)
+		retryMaxWait := time.Duration(d.Get("retry_max_wait").(int)) * time.Second
 
 		// Warning or errors can be collected in a slice type
 		var diags diag.Diagnostics
@@ -238,8 +406,25 @@ func providerConfigure(version string, testing bool) schema.ConfigureContextFunc
 			},
 		}
 
+		tlsOpts, err := providerTLSOptions(d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		tlsTransport, err := GetTLSTransport(tlsOpts)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 		config.HTTPClient = &http.Client{
-			Transport: GetTLSTransport(insecure),
+			Transport: NewRetryTransport(tlsTransport, maxRetries, retryMaxWait),
+		}
+		source, di := providerTokenSource(d, apiURL, config.HTTPClient)
+		if di != nil {
+			return nil, di
+		}
+		if source != nil {
+			config.HTTPClient = &http.Client{
+				Transport: NewAuthTransport(config.HTTPClient.Transport, source),
+			}
 		}
 		if testing {
 			config.HTTPClient = &http.Client{
@@ -247,7 +432,12 @@ func providerConfigure(version string, testing bool) schema.ConfigureContextFunc
 			}
 		}
 
-		config.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+		if source == nil {
+			if token == "" {
+				return nil, diag.Errorf("No credentials configured, one of `token`, `token_file`, `token_command` or `auth` must be set")
+			}
+			config.AddDefaultHeader("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
 		if _headers, ok := d.GetOk("headers"); ok {
 			headers := _headers.(map[string]any)
 			for headerName, headerValue := range headers {
@@ -276,13 +466,18 @@ func providerConfigure(version string, testing bool) schema.ConfigureContextFunc
 			if err != nil {
 				fmt.Printf("Error during sentry init: %v\n", err)
 			} else {
-				config.HTTPClient.Transport = NewTracingTransport(context.Background(), config.HTTPClient.Transport)
+				config.HTTPClient.Transport = NewTracingTransport(config.HTTPClient.Transport)
 				apiClient = api.NewAPIClient(config)
 			}
 		}
 
 		return &APIClient{
-			client: apiClient,
+			client:           apiClient,
+			pageSize:         d.Get("page_size").(int),
+			serverVersion:    fetchServerVersion(context.Background(), apiClient),
+			adoptExisting:    d.Get("adopt_existing").(bool),
+			protectedObjects: helpers.CastSlice[string](d, "protected_objects"),
+			allowLockout:     d.Get("allow_lockout").(bool),
 		}, diags
 	}
 }
@@ -313,28 +508,84 @@ func (tt *TestingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	}, nil
 }
 
-// GetTLSTransport Get a TLS transport instance, that skips verification if configured via environment variables.
-func GetTLSTransport(insecure bool) http.RoundTripper {
+// TLSOptions TLS settings used for the connection to authentik
+type TLSOptions struct {
+	// Skip verification of the server certificate
+	Insecure bool
+	// PEM encoded CA certificates trusted in addition to the system's CA certificates
+	CACertPEM []byte
+	// PEM encoded client certificate and key used for mutual TLS
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// Server name used to verify the server certificate, if it differs from the host
+	ServerName string
+}
+
+// GetTLSTransport Get a TLS transport instance, configured with custom CA certificates and client certificates
+// and that skips verification if configured.
+func GetTLSTransport(opts TLSOptions) (http.RoundTripper, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: opts.Insecure,
+		ServerName:         opts.ServerName,
+	}
+	if len(opts.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no valid CA certificates found")
+		}
+		config.RootCAs = pool
+	}
+	if len(opts.ClientCertPEM) > 0 || len(opts.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: insecure,
-		},
-		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: config,
+		Proxy:           http.ProxyFromEnvironment,
+	}
+	return transport, nil
+}
+
+// providerTLSOptions Get the TLS options configured for the provider
+func providerTLSOptions(d *schema.ResourceData) (TLSOptions, error) {
+	opts := TLSOptions{
+		Insecure:      d.Get("insecure").(bool),
+		ServerName:    d.Get("tls_server_name").(string),
+		ClientCertPEM: []byte(d.Get("client_cert").(string)),
+		ClientKeyPEM:  []byte(d.Get("client_key").(string)),
+	}
+	if path, ok := d.GetOk("ca_cert_file"); ok {
+		pem, err := os.ReadFile(path.(string))
+		if err != nil {
+			return opts, fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+		opts.CACertPEM = append(opts.CACertPEM, pem...)
+		opts.CACertPEM = append(opts.CACertPEM, '\n')
+	}
+	if pem, ok := d.GetOk("ca_cert_pem"); ok {
+		opts.CACertPEM = append(opts.CACertPEM, []byte(pem.(string))...)
 	}
-	return transport
+	return opts, nil
 }
 
 type tracingTransport struct {
 	inner http.RoundTripper
-	ctx   context.Context
 }
 
-func NewTracingTransport(ctx context.Context, inner http.RoundTripper) *tracingTransport {
-	return &tracingTransport{inner, ctx}
+func NewTracingTransport(inner http.RoundTripper) *tracingTransport {
+	return &tracingTransport{inner}
 }
 
 func (tt *tracingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	span := sentry.StartSpan(tt.ctx, "authentik.go.http_request")
+	// Start the span from the request's context, so its cancellation and values such as the
+	// create retry state are kept
+	span := sentry.StartSpan(r.Context(), "authentik.go.http_request")
 	r.Header.Set("sentry-trace", span.ToSentryTrace())
 	span.Description = fmt.Sprintf("%s %s", r.Method, r.URL.String())
 	span.SetTag("url", r.URL.String())
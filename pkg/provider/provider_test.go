@@ -1,8 +1,19 @@
 package provider
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
@@ -90,7 +101,7 @@ func TestProviderConfigure_PathBasedURL(t *testing.T) {
 
 			_ac, diag := p.ConfigureContextFunc(t.Context(), schema.TestResourceDataRaw(t, p.Schema, map[string]any{
 				"url":      tc.inputURL,
-				"token":    "",
+				"token":    "test-token",
 				"insecure": false,
 			}))
 			assert.Nil(t, diag)
@@ -99,3 +110,91 @@ func TestProviderConfigure_PathBasedURL(t *testing.T) {
 		})
 	}
 }
+
+// testClientCertificate Generate a self-signed client certificate and key, PEM encoded
+func testClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestGetTLSTransport_CustomCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	transport, err := GetTLSTransport(TLSOptions{})
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.Error(t, err)
+
+	transport, err = GetTLSTransport(TLSOptions{
+		CACertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}),
+	})
+	assert.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestGetTLSTransport_ClientCertificate(t *testing.T) {
+	cert, key := testClientCertificate(t)
+	pool := x509.NewCertPool()
+	assert.True(t, pool.AppendCertsFromPEM(cert))
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "terraform", r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	transport, err := GetTLSTransport(TLSOptions{
+		CACertPEM: caPEM,
+	})
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.Error(t, err)
+
+	transport, err = GetTLSTransport(TLSOptions{
+		CACertPEM:     caPEM,
+		ClientCertPEM: cert,
+		ClientKeyPEM:  key,
+	})
+	assert.NoError(t, err)
+	res, err := (&http.Client{Transport: transport}).Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestGetTLSTransport_Invalid(t *testing.T) {
+	_, err := GetTLSTransport(TLSOptions{
+		CACertPEM: []byte("foo"),
+	})
+	assert.Error(t, err)
+
+	cert, _ := testClientCertificate(t)
+	_, err = GetTLSTransport(TLSOptions{
+		ClientCertPEM: cert,
+	})
+	assert.Error(t, err)
+}
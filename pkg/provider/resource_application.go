@@ -17,7 +17,7 @@ func resourceApplication() *schema.Resource {
 		UpdateContext: resourceApplicationUpdate,
 		DeleteContext: resourceApplicationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importApplicationKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -81,6 +81,7 @@ func resourceApplication() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -164,6 +165,9 @@ func resourceApplicationUpdate(ctx context.Context, d *schema.ResourceData, m an
 
 func resourceApplicationDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "application", d.Get("slug").(string)); diags != nil {
+		return diags
+	}
 	hr, err := c.client.CoreAPI.CoreApplicationsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
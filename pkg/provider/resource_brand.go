@@ -102,6 +102,9 @@ func resourceBrand() *schema.Resource {
 				DiffSuppressFunc: helpers.DiffSuppressJSON,
 				ValidateDiagFunc: helpers.ValidateJSON,
 			},
+			"attributes_mode":        attributesModeSchema(),
+			"managed_attribute_keys": managedAttributeKeysSchema(),
+			"deletion_protection":    deletionProtectionSchema(),
 		},
 	}
 }
@@ -146,6 +149,7 @@ func resourceBrandCreate(ctx context.Context, d *schema.ResourceData, m any) dia
 	}
 
 	d.SetId(res.BrandUuid)
+	setManagedAttributeKeys(d, mo.Attributes)
 	return resourceBrandRead(ctx, d, m)
 }
 
@@ -177,7 +181,7 @@ func resourceBrandRead(ctx context.Context, d *schema.ResourceData, m any) diag.
 		res.ClientCertificates,
 	))
 	helpers.SetWrapper(d, "default_application", res.DefaultApplication.Get())
-	return helpers.SetJSON(d, "attributes", res.Attributes)
+	return setStateAttributes(d, res.Attributes)
 }
 
 func resourceBrandUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -188,6 +192,16 @@ func resourceBrandUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 		return diags
 	}
 
+	obj.Attributes, diags = resourceAttributes(d, func() (map[string]any, diag.Diagnostics) {
+		res, hr, err := c.client.CoreAPI.CoreBrandsRetrieve(ctx, d.Id()).Execute()
+		if err != nil {
+			return nil, helpers.HTTPToDiag(d, hr, err)
+		}
+		return res.Attributes, nil
+	})
+	if diags != nil {
+		return diags
+	}
 	res, hr, err := c.client.CoreAPI.CoreBrandsUpdate(ctx, d.Id()).BrandRequest(*obj).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
@@ -199,6 +213,9 @@ func resourceBrandUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 
 func resourceBrandDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "brand", d.Get("domain").(string)); diags != nil {
+		return diags
+	}
 	hr, err := c.client.CoreAPI.CoreBrandsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
@@ -17,7 +17,7 @@ func resourceFlow() *schema.Resource {
 		UpdateContext: resourceFlowUpdate,
 		DeleteContext: resourceFlowDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importFlowKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -79,6 +79,8 @@ func resourceFlow() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"adopt_existing":      adoptExistingSchema("slug"),
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -105,6 +107,11 @@ func resourceFlowCreate(ctx context.Context, d *schema.ResourceData, m any) diag
 	app := resourceFlowSchemaToModel(d)
 
 	res, hr, err := c.client.FlowsAPI.FlowsInstancesCreate(ctx).FlowRequest(*app).Execute()
+	if err != nil && c.shouldAdopt(d) && isUniqueConflict(hr, "slug") {
+		return adoptExisting(d, "flow", app.Slug, app.Slug, func() diag.Diagnostics {
+			return resourceFlowUpdate(ctx, d, m)
+		})
+	}
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
@@ -154,6 +161,9 @@ func resourceFlowUpdate(ctx context.Context, d *schema.ResourceData, m any) diag
 
 func resourceFlowDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "flow", d.Get("slug").(string)); diags != nil {
+		return diags
+	}
 	hr, err := c.client.FlowsAPI.FlowsInstancesDestroy(ctx, d.Id()).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
@@ -17,7 +17,7 @@ func resourceFlowStageBinding() *schema.Resource {
 		UpdateContext: resourceFlowStageBindingUpdate,
 		DeleteContext: resourceFlowStageBindingDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importFlowStageBinding,
 		},
 		Schema: map[string]*schema.Schema{
 			"target": {
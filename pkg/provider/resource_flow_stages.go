@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+// Gap between the order of two consecutive stages, so stages can be inserted in between
+const flowStagesOrderStep = 10
+
+func resourceFlowStages() *schema.Resource {
+	return &schema.Resource{
+		Description: "Flows & Stages --- Authoritative list of the stages bound to a flow. " +
+			"Stages are bound in the order they are listed, and bindings to the flow which are not listed are removed. " +
+			"Should not be combined with `authentik_flow_stage_binding` for the same flow.",
+		CreateContext: resourceFlowStagesCreate,
+		ReadContext:   resourceFlowStagesRead,
+		UpdateContext: resourceFlowStagesUpdate,
+		DeleteContext: resourceFlowStagesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			if d.HasChange("binding") {
+				return d.SetNewComputed("binding_ids")
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"flow": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the flow.",
+			},
+			"binding": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Stages bound to the flow, in the order they are executed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stage": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"evaluate_on_plan": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Evaluate policies during the Flow planning process.",
+						},
+						"re_evaluate_policies": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Evaluate policies when the Stage is present to the user.",
+						},
+						"policy_engine_mode": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          api.POLICYENGINEMODE_ANY,
+							Description:      helpers.EnumToDescription(api.AllowedPolicyEngineModeEnumValues),
+							ValidateDiagFunc: helpers.StringInEnum(api.AllowedPolicyEngineModeEnumValues),
+						},
+						"invalid_response_action": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          api.INVALIDRESPONSEACTIONENUM_RETRY,
+							Description:      helpers.EnumToDescription(api.AllowedInvalidResponseActionEnumEnumValues),
+							ValidateDiagFunc: helpers.StringInEnum(api.AllowedInvalidResponseActionEnumEnumValues),
+						},
+					},
+				},
+			},
+			"binding_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the stage bindings, in the same order as `binding`. Can be used as `target` for policy bindings.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceFlowStagesBindingToModel(target string, idx int, raw map[string]any) api.FlowStageBindingRequest {
+	return api.FlowStageBindingRequest{
+		Target:                target,
+		Stage:                 raw["stage"].(string),
+		Order:                 int32(idx * flowStagesOrderStep),
+		EvaluateOnPlan:        new(raw["evaluate_on_plan"].(bool)),
+		ReEvaluatePolicies:    new(raw["re_evaluate_policies"].(bool)),
+		PolicyEngineMode:      api.PolicyEngineMode(raw["policy_engine_mode"].(string)).Ptr(),
+		InvalidResponseAction: api.InvalidResponseActionEnum(raw["invalid_response_action"].(string)).Ptr(),
+	}
+}
+
+// resourceFlowStagesBindingMatches Check if an existing binding already matches the desired binding
+func resourceFlowStagesBindingMatches(current api.FlowStageBinding, desired api.FlowStageBindingRequest) bool {
+	return current.GetStage() == desired.Stage &&
+		current.GetOrder() == desired.Order &&
+		current.GetEvaluateOnPlan() == desired.GetEvaluateOnPlan() &&
+		current.GetReEvaluatePolicies() == desired.GetReEvaluatePolicies() &&
+		current.GetPolicyEngineMode() == desired.GetPolicyEngineMode() &&
+		current.GetInvalidResponseAction() == desired.GetInvalidResponseAction()
+}
+
+// resourceFlowStagesList Get all stage bindings of a flow, sorted by their order
+func resourceFlowStagesList(ctx context.Context, d *schema.ResourceData, c *APIClient, flow string) ([]api.FlowStageBinding, diag.Diagnostics) {
+	bindings, hr, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsBindingsList(ctx).Target(flow), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	slices.SortStableFunc(bindings, func(a, b api.FlowStageBinding) int {
+		return int(a.GetOrder()) - int(b.GetOrder())
+	})
+	return bindings, nil
+}
+
+// resourceFlowStagesApply Reconcile the bindings of the flow with the configuration. Existing bindings
+// of the same stage are updated in place, new ones are created and bindings which aren't
+// configured anymore are removed last, so the flow isn't left without stages in between.
+func resourceFlowStagesApply(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	flow, hr, err := c.client.FlowsAPI.FlowsInstancesRetrieve(ctx, d.Get("flow").(string)).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	unused, diags := resourceFlowStagesList(ctx, d, c, flow.Pk)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, raw := range d.Get("binding").([]any) {
+		desired := resourceFlowStagesBindingToModel(flow.Pk, i, raw.(map[string]any))
+		idx := slices.IndexFunc(unused, func(b api.FlowStageBinding) bool {
+			return b.GetStage() == desired.Stage
+		})
+		if idx < 0 {
+			_, hr, err := c.client.FlowsAPI.FlowsBindingsCreate(ctx).FlowStageBindingRequest(desired).Execute()
+			if err != nil {
+				return helpers.HTTPToDiag(d, hr, err)
+			}
+			continue
+		}
+		current := unused[idx]
+		unused = slices.Delete(unused, idx, idx+1)
+		if resourceFlowStagesBindingMatches(current, desired) {
+			continue
+		}
+		_, hr, err := c.client.FlowsAPI.FlowsBindingsUpdate(ctx, current.Pk).FlowStageBindingRequest(desired).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	for _, binding := range unused {
+		hr, err := c.client.FlowsAPI.FlowsBindingsDestroy(ctx, binding.Pk).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+
+	d.SetId(flow.Slug)
+	return resourceFlowStagesRead(ctx, d, m)
+}
+
+func resourceFlowStagesCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return resourceFlowStagesApply(ctx, d, m)
+}
+
+func resourceFlowStagesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	flow, hr, err := c.client.FlowsAPI.FlowsInstancesRetrieve(ctx, d.Id()).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	bindings, diags := resourceFlowStagesList(ctx, d, c, flow.Pk)
+	if diags.HasError() {
+		return diags
+	}
+
+	configured := make([]map[string]any, len(bindings))
+	ids := make([]string, len(bindings))
+	for i, binding := range bindings {
+		configured[i] = map[string]any{
+			"stage":                   binding.GetStage(),
+			"evaluate_on_plan":        binding.GetEvaluateOnPlan(),
+			"re_evaluate_policies":    binding.GetReEvaluatePolicies(),
+			"policy_engine_mode":      string(binding.GetPolicyEngineMode()),
+			"invalid_response_action": string(binding.GetInvalidResponseAction()),
+		}
+		ids[i] = binding.Pk
+	}
+	helpers.SetWrapper(d, "flow", flow.Slug)
+	helpers.SetWrapper(d, "binding", configured)
+	helpers.SetWrapper(d, "binding_ids", ids)
+	return diag.Diagnostics{}
+}
+
+func resourceFlowStagesUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return resourceFlowStagesApply(ctx, d, m)
+}
+
+func resourceFlowStagesDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+	for _, id := range helpers.CastSlice[string](d, "binding_ids") {
+		hr, err := c.client.FlowsAPI.FlowsBindingsDestroy(ctx, id).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return diag.Diagnostics{}
+}
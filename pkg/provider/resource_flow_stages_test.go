@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceFlowStages(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceFlowStagesSimple(rName, "first", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "flow", rName),
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "binding.#", "2"),
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "binding_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("authentik_flow_stages.stages", "binding.0.stage", "authentik_stage_dummy.first", "id"),
+				),
+			},
+			{
+				Config: testAccResourceFlowStagesSimple(rName, "second", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "binding.#", "2"),
+					resource.TestCheckResourceAttrPair("authentik_flow_stages.stages", "binding.0.stage", "authentik_stage_dummy.second", "id"),
+				),
+			},
+			{
+				Config: testAccResourceFlowStagesSimple(rName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "binding.#", "1"),
+					resource.TestCheckResourceAttr("authentik_flow_stages.stages", "binding_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceFlowStagesSimple(name string, stages ...string) string {
+	bindings := ""
+	for _, stage := range stages {
+		bindings += fmt.Sprintf(`
+  binding {
+    stage = authentik_stage_dummy.%s.id
+  }
+`, stage)
+	}
+	return fmt.Sprintf(`
+resource "authentik_stage_dummy" "first" {
+  name = "%[1]s-first"
+}
+
+resource "authentik_stage_dummy" "second" {
+  name = "%[1]s-second"
+}
+
+resource "authentik_flow" "flow" {
+  name        = "%[1]s"
+  title       = "%[1]s"
+  slug        = "%[1]s"
+  designation = "authorization"
+}
+
+resource "authentik_flow_stages" "stages" {
+  flow = authentik_flow.flow.slug
+%[2]s}
+`, name, bindings)
+}
@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +19,7 @@ func resourceGroup() *schema.Resource {
 		UpdateContext: resourceGroupUpdate,
 		DeleteContext: resourceGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importGroupKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -37,9 +39,10 @@ func resourceGroup() *schema.Resource {
 				},
 			},
 			"users": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Leave unset when members are managed with `authentik_group_membership` or `authentik_group_members`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
@@ -53,12 +56,18 @@ func resourceGroup() *schema.Resource {
 				ValidateDiagFunc: helpers.ValidateJSON,
 			},
 			"roles": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Leave unset when roles are assigned with `authentik_rbac_role_assignment`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"attributes_mode":        attributesModeSchema(),
+			"managed_attribute_keys": managedAttributeKeysSchema(),
+			"adopt_existing":         adoptExistingSchema("name"),
+			"deletion_protection":    deletionProtectionSchema(),
 		},
 	}
 }
@@ -76,6 +85,47 @@ func resourceGroupSchemaToModel(d *schema.ResourceData) (*api.GroupRequest, diag
 	return &m, err
 }
 
+// resourceGroupCheckLockout Prevent removing superuser permissions from the user the provider authenticates as
+// or the last active superuser, either by deleting the group, disabling `is_superuser` or removing members
+func resourceGroupCheckLockout(ctx context.Context, d *schema.ResourceData, c *APIClient, deleting bool) diag.Diagnostics {
+	wasSuperuser, _ := d.GetChange("is_superuser")
+	if !wasSuperuser.(bool) {
+		return nil
+	}
+	state, diags := c.lockoutCurrentState(ctx, d)
+	if diags != nil || state == nil {
+		return diags
+	}
+	groups, diags := c.superuserGroups(ctx, d)
+	if diags != nil {
+		return diags
+	}
+	members := groups[d.Id()]
+	remaining := helpers.CastSliceInt32(d.Get("users").([]any))
+	affected := []int32{}
+	for _, pk := range members {
+		if !deleting && d.Get("is_superuser").(bool) && slices.Contains(remaining, pk) {
+			continue
+		}
+		// Members of other superuser groups keep their permissions
+		other := false
+		for group, users := range groups {
+			if group != d.Id() && slices.Contains(users, pk) {
+				other = true
+				break
+			}
+		}
+		if !other {
+			affected = append(affected, pk)
+		}
+	}
+	action := fmt.Sprintf("Changing group '%s'", d.Get("name").(string))
+	if deleting {
+		action = fmt.Sprintf("Deleting group '%s'", d.Get("name").(string))
+	}
+	return state.check(action, affected)
+}
+
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
 
@@ -85,11 +135,21 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m any) dia
 	}
 
 	res, hr, err := c.client.CoreAPI.CoreGroupsCreate(ctx).GroupRequest(*app).Execute()
+	if err != nil && c.shouldAdopt(d) && isUniqueConflict(hr, "name") {
+		id, err := importGroupByName(ctx, c, app.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return adoptExisting(d, "group", app.Name, id, func() diag.Diagnostics {
+			return resourceGroupUpdate(ctx, d, m)
+		})
+	}
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
 
 	d.SetId(res.Pk)
+	setManagedAttributeKeys(d, app.Attributes)
 	return resourceGroupRead(ctx, d, m)
 }
 
@@ -112,10 +172,10 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m any) diag.
 		helpers.Slice32ToInt(res.Users),
 	))
 	helpers.SetWrapper(d, "roles", helpers.ListConsistentMerge(
-		helpers.CastSlice[string](d, "role"),
+		helpers.CastSlice[string](d, "roles"),
 		res.Roles,
 	))
-	return helpers.SetJSON(d, "attributes", res.Attributes)
+	return setStateAttributes(d, res.Attributes)
 }
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -125,6 +185,39 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 	if di != nil {
 		return di
 	}
+	if d.HasChanges("is_superuser", "users") {
+		if diags := resourceGroupCheckLockout(ctx, d, c, false); diags != nil {
+			return diags
+		}
+	}
+	app.Attributes, di = resourceAttributes(d, func() (map[string]any, diag.Diagnostics) {
+		res, hr, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, d.Id()).IncludeUsers(false).Execute()
+		if err != nil {
+			return nil, helpers.HTTPToDiag(d, hr, err)
+		}
+		return res.Attributes, nil
+	})
+	if di != nil {
+		return di
+	}
+	if d.GetRawConfig().GetAttr("users").IsNull() {
+		// Members aren't managed by this resource, for example when they're managed with
+		// `authentik_group_membership`, so only the other fields are updated
+		req := api.PatchedGroupRequest{
+			Name:        new(app.Name),
+			IsSuperuser: app.IsSuperuser,
+			Parents:     app.Parents,
+			Attributes:  app.Attributes,
+		}
+		if !d.GetRawConfig().GetAttr("roles").IsNull() {
+			req.Roles = app.Roles
+		}
+		_, hr, err := c.client.CoreAPI.CoreGroupsPartialUpdate(ctx, d.Id()).PatchedGroupRequest(req).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		return resourceGroupRead(ctx, d, m)
+	}
 	res, hr, err := c.client.CoreAPI.CoreGroupsUpdate(ctx, d.Id()).GroupRequest(*app).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
@@ -136,6 +229,12 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m any) dia
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "group", d.Get("name").(string)); diags != nil {
+		return diags
+	}
+	if diags := resourceGroupCheckLockout(ctx, d, c, true); diags != nil {
+		return diags
+	}
 	hr, err := c.client.CoreAPI.CoreGroupsDestroy(ctx, d.Id()).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
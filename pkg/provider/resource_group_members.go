@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+func resourceGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Directory --- Add users to a group without managing the other members of the group. " +
+			"Several of these resources can add members to the same group. " +
+			"Don't combine with `users` on `authentik_group` or `groups` on `authentik_user` for the same group.",
+		CreateContext: resourceGroupMembersCreate,
+		ReadContext:   resourceGroupMembersRead,
+		UpdateContext: resourceGroupMembersUpdate,
+		DeleteContext: resourceGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the group.",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The IDs of the users to add to the group. Other members of the group are not affected.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceGroupMembersCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	group := d.Get("group").(string)
+	users := helpers.CastSliceInt32(d.Get("users").(*schema.Set).List())
+	if diags := groupAddUsers(ctx, d, c, group, users); diags != nil {
+		return diags
+	}
+	// Several resources can manage members of the same group, so the group alone is not unique
+	d.SetId(fmt.Sprintf("%s:%s", group, id.UniqueId()))
+	return resourceGroupMembersRead(ctx, d, m)
+}
+
+// resourceGroupMembersGroup Get the group UUID from the resource ID
+func resourceGroupMembersGroup(d *schema.ResourceData) string {
+	group, _, _ := strings.Cut(d.Id(), ":")
+	return group
+}
+
+// resourceGroupMembersImport Import either all current members of a group by its UUID,
+// or only some of them in the form of `<group>:<user>,<user>`
+func resourceGroupMembersImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	c := m.(*APIClient)
+
+	group, rawUsers, hasUsers := strings.Cut(d.Id(), ":")
+	res, _, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, group).IncludeUsers(false).Execute()
+	if err != nil {
+		return nil, err
+	}
+	users := helpers.Slice32ToInt(res.Users)
+	if hasUsers {
+		users = []int{}
+		for _, raw := range strings.Split(rawUsers, ",") {
+			pk, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid import ID %q, expected `<group>` or `<group>:<user>,<user>`", d.Id())
+			}
+			users = append(users, pk)
+		}
+	}
+	d.SetId(fmt.Sprintf("%s:%s", group, id.UniqueId()))
+	helpers.SetWrapper(d, "group", group)
+	helpers.SetWrapper(d, "users", users)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGroupMembersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	group := resourceGroupMembersGroup(d)
+	res, hr, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, group).IncludeUsers(false).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	helpers.SetWrapper(d, "group", group)
+	managed := helpers.CastSliceInt32(d.Get("users").(*schema.Set).List())
+	users := []int{}
+	for _, pk := range managed {
+		if slices.Contains(res.Users, pk) {
+			users = append(users, int(pk))
+		}
+	}
+	helpers.SetWrapper(d, "users", users)
+	return nil
+}
+
+func resourceGroupMembersUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	o, n := d.GetChange("users")
+	removed := helpers.CastSliceInt32(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+	added := helpers.CastSliceInt32(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+	if len(removed) > 0 {
+		if diags := groupRemoveUsers(ctx, d, c, resourceGroupMembersGroup(d), removed); diags != nil {
+			return diags
+		}
+	}
+	if diags := groupAddUsers(ctx, d, c, resourceGroupMembersGroup(d), added); diags != nil {
+		return diags
+	}
+	return resourceGroupMembersRead(ctx, d, m)
+}
+
+func resourceGroupMembersDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	users := helpers.CastSliceInt32(d.Get("users").(*schema.Set).List())
+	if diags := groupRemoveUsers(ctx, d, c, resourceGroupMembersGroup(d), users); diags != nil {
+		return diags
+	}
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+func resourceGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Description: "Directory --- Add a single user to a group without managing the other members of the group. " +
+			"Don't combine with `users` on `authentik_group` or `groups` on `authentik_user` for the same group.",
+		CreateContext: resourceGroupMembershipCreate,
+		ReadContext:   resourceGroupMembershipRead,
+		DeleteContext: resourceGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the group.",
+			},
+			"user": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the user.",
+			},
+		},
+	}
+}
+
+// groupAddUsers Add users to a group, keeping its other members
+func groupAddUsers(ctx context.Context, d *schema.ResourceData, c *APIClient, group string, users []int32) diag.Diagnostics {
+	for _, pk := range users {
+		hr, err := c.client.CoreAPI.CoreGroupsAddUserCreate(ctx, group).UserAccountRequest(api.UserAccountRequest{
+			Pk: pk,
+		}).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return nil
+}
+
+// groupRemoveUsers Remove users from a group, keeping its other members
+func groupRemoveUsers(ctx context.Context, d *schema.ResourceData, c *APIClient, group string, users []int32) diag.Diagnostics {
+	if diags := c.checkGroupMembersRemoval(ctx, d, group, users); diags != nil {
+		return diags
+	}
+	for _, pk := range users {
+		hr, err := c.client.CoreAPI.CoreGroupsRemoveUserCreate(ctx, group).UserAccountRequest(api.UserAccountRequest{
+			Pk: pk,
+		}).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return nil
+}
+
+func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	group := d.Get("group").(string)
+	user := int32(d.Get("user").(int))
+	if diags := groupAddUsers(ctx, d, c, group, []int32{user}); diags != nil {
+		return diags
+	}
+	d.SetId(fmt.Sprintf("%s:%d", group, user))
+	return resourceGroupMembershipRead(ctx, d, m)
+}
+
+func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	group, user, ok := strings.Cut(d.Id(), ":")
+	if !ok {
+		return diag.Errorf("Invalid ID format, expected `group:user`")
+	}
+	pk, err := strconv.ParseInt(user, 10, 32)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, hr, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, group).IncludeUsers(false).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	if !slices.Contains(res.Users, int32(pk)) {
+		d.SetId("")
+		return nil
+	}
+	helpers.SetWrapper(d, "group", group)
+	helpers.SetWrapper(d, "user", int(pk))
+	return nil
+}
+
+func resourceGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	user := int32(d.Get("user").(int))
+	if diags := groupRemoveUsers(ctx, d, c, d.Get("group").(string), []int32{user}); diags != nil {
+		return diags
+	}
+	return diag.Diagnostics{}
+}
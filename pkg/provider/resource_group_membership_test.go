@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGroupMembership(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMembership(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("authentik_group_membership.membership", "group", "authentik_group.group", "id"),
+					resource.TestCheckResourceAttrPair("authentik_group_members.members", "group", "authentik_group.group", "id"),
+					resource.TestCheckResourceAttr("authentik_group_members.members", "users.#", "2"),
+				),
+			},
+			{
+				// Updating the users keeps the memberships managed by other resources
+				Config: testAccResourceGroupMembership(rName, rName+"-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.first", "name", rName+"-updated"),
+					resource.TestCheckResourceAttr("authentik_user.first", "groups.#", "1"),
+					resource.TestCheckResourceAttrPair("authentik_user.first", "groups.0", "authentik_group.group", "id"),
+					resource.TestCheckResourceAttr("authentik_group_members.members", "users.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "authentik_group_membership.membership",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "authentik_group_members.members",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["authentik_group_members.members"]
+					return rs.Primary.Attributes["group"], nil
+				},
+				ImportStateCheck: func(is []*terraform.InstanceState) error {
+					// Importing by the group manages all of its members
+					if users := is[0].Attributes["users.#"]; users != "3" {
+						return fmt.Errorf("expected 3 imported members, got %s", users)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccResourceGroupMembership(name string, userName string) string {
+	return fmt.Sprintf(`
+resource "authentik_user" "first" {
+  username = "%[1]s-first"
+  name = "%[2]s"
+}
+resource "authentik_user" "second" {
+  username = "%[1]s-second"
+  name = "%[2]s"
+}
+resource "authentik_user" "third" {
+  username = "%[1]s-third"
+  name = "%[2]s"
+}
+resource "authentik_group" "group" {
+  name = "%[1]s"
+}
+resource "authentik_group_membership" "membership" {
+  group = authentik_group.group.id
+  user  = authentik_user.first.id
+}
+resource "authentik_group_members" "members" {
+  group = authentik_group.group.id
+  users = [authentik_user.second.id, authentik_user.third.id]
+}
+`, name, userName)
+}
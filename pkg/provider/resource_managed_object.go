@@ -0,0 +1,491 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+const (
+	managedObjectOnDestroyKeep    = "keep"
+	managedObjectOnDestroyRestore = "restore"
+)
+
+// managedObjectModel How objects of a model are looked up and where they're updated. Objects are
+// found with the typed client, their configured fields are arbitrary and read and updated as JSON.
+type managedObjectModel struct {
+	// Field `identifier` is matched against
+	identifier string
+	// Whether objects of this model have a `managed` key
+	managed bool
+	// Find the PK of the object whose `field` is `value`, empty when there's no such object
+	find func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error)
+	// Get the API path of the object with the given PK, empty when the object doesn't exist
+	path func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error)
+}
+
+var managedObjectModels = map[string]managedObjectModel{
+	"brand": {
+		identifier: "domain",
+		find: func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error) {
+			res, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreBrandsList(ctx).Domain(value), helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil {
+				return "", hr, err
+			}
+			for _, b := range res {
+				if b.Domain == value {
+					return b.BrandUuid, hr, nil
+				}
+			}
+			return "", hr, nil
+		},
+		path: func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error) {
+			res, hr, err := c.client.CoreAPI.CoreBrandsRetrieve(ctx, pk).Execute()
+			if err != nil {
+				return managedObjectNotFound(hr, err)
+			}
+			return fmt.Sprintf("/core/brands/%s/", res.BrandUuid), hr, nil
+		},
+	},
+	"flow": {
+		identifier: "slug",
+		find: func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error) {
+			res, hr, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsInstancesList(ctx).Slug(value), helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil {
+				return "", hr, err
+			}
+			for _, f := range res {
+				if f.Slug == value {
+					return f.Pk, hr, nil
+				}
+			}
+			return "", hr, nil
+		},
+		// Flows are addressed by their slug, which can change
+		path: func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error) {
+			res, hr, err := helpers.Paginator(ctx, c.client.FlowsAPI.FlowsInstancesList(ctx).FlowUuid(pk), helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil || len(res) < 1 {
+				return "", hr, err
+			}
+			return fmt.Sprintf("/flows/instances/%s/", res[0].Slug), hr, nil
+		},
+	},
+	"stage": {
+		identifier: "name",
+		find: func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error) {
+			res, hr, err := helpers.Paginator(ctx, c.client.StagesAPI.StagesAllList(ctx).Name(value), helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil {
+				return "", hr, err
+			}
+			for _, s := range res {
+				if s.Name == value {
+					return s.Pk, hr, nil
+				}
+			}
+			return "", hr, nil
+		},
+		path: func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error) {
+			res, hr, err := c.client.StagesAPI.StagesAllRetrieve(ctx, pk).Execute()
+			if err != nil {
+				return managedObjectNotFound(hr, err)
+			}
+			path, err := managedObjectEndpoint(managedObjectStageEndpoints, res.MetaModelName, res.Pk)
+			return path, hr, err
+		},
+	},
+	"source": {
+		identifier: "slug",
+		managed:    true,
+		find: func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error) {
+			req := c.client.SourcesAPI.SourcesAllList(ctx).Slug(value)
+			if field == "managed" {
+				req = c.client.SourcesAPI.SourcesAllList(ctx).Managed(value)
+			}
+			res, hr, err := helpers.Paginator(ctx, req, helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil {
+				return "", hr, err
+			}
+			for _, s := range res {
+				if (field == "managed" && s.GetManaged() == value) || (field != "managed" && s.Slug == value) {
+					return s.Pk, hr, nil
+				}
+			}
+			return "", hr, nil
+		},
+		// Sources are addressed by their slug, which can change
+		path: func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error) {
+			res, hr, err := helpers.Paginator(ctx, c.client.SourcesAPI.SourcesAllList(ctx).PbmUuid(pk), helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil || len(res) < 1 {
+				return "", hr, err
+			}
+			path, err := managedObjectEndpoint(managedObjectSourceEndpoints, res[0].MetaModelName, res[0].Slug)
+			return path, hr, err
+		},
+	},
+	"property_mapping": {
+		identifier: "name",
+		managed:    true,
+		find: func(ctx context.Context, c *APIClient, field string, value string) (string, *http.Response, error) {
+			req := c.client.PropertymappingsAPI.PropertymappingsAllList(ctx).Name(value)
+			if field == "managed" {
+				req = c.client.PropertymappingsAPI.PropertymappingsAllList(ctx).Managed([]string{value})
+			}
+			res, hr, err := helpers.Paginator(ctx, req, helpers.PaginatorOptions{
+				PageSize: c.pageSize,
+			})
+			if err != nil {
+				return "", hr, err
+			}
+			for _, p := range res {
+				if (field == "managed" && p.GetManaged() == value) || (field != "managed" && p.Name == value) {
+					return p.Pk, hr, nil
+				}
+			}
+			return "", hr, nil
+		},
+		path: func(ctx context.Context, c *APIClient, pk string) (string, *http.Response, error) {
+			res, hr, err := c.client.PropertymappingsAPI.PropertymappingsAllRetrieve(ctx, pk).Execute()
+			if err != nil {
+				return managedObjectNotFound(hr, err)
+			}
+			path, err := managedObjectEndpoint(managedObjectPropertyMappingEndpoints, res.MetaModelName, res.Pk)
+			return path, hr, err
+		},
+	},
+}
+
+// Endpoints of stages by app label
+var managedObjectStageEndpoints = map[string]string{
+	"authentik_endpoints":                          "/stages/endpoints/",
+	"authentik_stages_account_lockdown":            "/stages/account_lockdown/",
+	"authentik_stages_authenticator_duo":           "/stages/authenticator/duo/",
+	"authentik_stages_authenticator_email":         "/stages/authenticator/email/",
+	"authentik_stages_authenticator_endpoint_gdtc": "/stages/authenticator/endpoint_gdtc/",
+	"authentik_stages_authenticator_sms":           "/stages/authenticator/sms/",
+	"authentik_stages_authenticator_static":        "/stages/authenticator/static/",
+	"authentik_stages_authenticator_totp":          "/stages/authenticator/totp/",
+	"authentik_stages_authenticator_validate":      "/stages/authenticator/validate/",
+	"authentik_stages_authenticator_webauthn":      "/stages/authenticator/webauthn/",
+	"authentik_stages_captcha":                     "/stages/captcha/",
+	"authentik_stages_consent":                     "/stages/consent/",
+	"authentik_stages_deny":                        "/stages/deny/",
+	"authentik_stages_dummy":                       "/stages/dummy/",
+	"authentik_stages_email":                       "/stages/email/",
+	"authentik_stages_identification":              "/stages/identification/",
+	"authentik_stages_invitation":                  "/stages/invitation/stages/",
+	"authentik_stages_mtls":                        "/stages/mtls/",
+	"authentik_stages_password":                    "/stages/password/",
+	"authentik_stages_prompt":                      "/stages/prompt/stages/",
+	"authentik_stages_redirect":                    "/stages/redirect/",
+	"authentik_stages_source":                      "/stages/source/",
+	"authentik_stages_user_delete":                 "/stages/user_delete/",
+	"authentik_stages_user_login":                  "/stages/user_login/",
+	"authentik_stages_user_logout":                 "/stages/user_logout/",
+	"authentik_stages_user_write":                  "/stages/user_write/",
+}
+
+// Endpoints of sources by app label
+var managedObjectSourceEndpoints = map[string]string{
+	"authentik_sources_kerberos": "/sources/kerberos/",
+	"authentik_sources_ldap":     "/sources/ldap/",
+	"authentik_sources_oauth":    "/sources/oauth/",
+	"authentik_sources_plex":     "/sources/plex/",
+	"authentik_sources_saml":     "/sources/saml/",
+	"authentik_sources_scim":     "/sources/scim/",
+	"authentik_sources_telegram": "/sources/telegram/",
+}
+
+// Endpoints of property mappings by app label
+var managedObjectPropertyMappingEndpoints = map[string]string{
+	"authentik_enterprise_providers_google_workspace": "/propertymappings/provider/google_workspace/",
+	"authentik_enterprise_providers_microsoft_entra":  "/propertymappings/provider/microsoft_entra/",
+	"authentik_events":           "/propertymappings/notification/",
+	"authentik_providers_oauth2": "/propertymappings/provider/scope/",
+	"authentik_providers_rac":    "/propertymappings/provider/rac/",
+	"authentik_providers_radius": "/propertymappings/provider/radius/",
+	"authentik_providers_saml":   "/propertymappings/provider/saml/",
+	"authentik_providers_scim":   "/propertymappings/provider/scim/",
+	"authentik_sources_kerberos": "/propertymappings/source/kerberos/",
+	"authentik_sources_ldap":     "/propertymappings/source/ldap/",
+	"authentik_sources_oauth":    "/propertymappings/source/oauth/",
+	"authentik_sources_plex":     "/propertymappings/source/plex/",
+	"authentik_sources_saml":     "/propertymappings/source/saml/",
+	"authentik_sources_scim":     "/propertymappings/source/scim/",
+}
+
+// managedObjectEndpoint Get the API path of an object from its model name like `authentik_stages_dummy.dummystage`
+func managedObjectEndpoint(endpoints map[string]string, model string, key string) (string, error) {
+	app, _, _ := strings.Cut(model, ".")
+	endpoint, ok := endpoints[app]
+	if !ok {
+		return "", fmt.Errorf("unsupported object type '%s'", model)
+	}
+	return endpoint + key + "/", nil
+}
+
+// managedObjectNotFound Treat objects which don't exist anymore as an empty path instead of an error
+func managedObjectNotFound(hr *http.Response, err error) (string, *http.Response, error) {
+	if hr != nil && hr.StatusCode == 404 {
+		return "", hr, nil
+	}
+	return "", hr, err
+}
+
+func resourceManagedObject() *schema.Resource {
+	return &schema.Resource{
+		Description: "System --- Manage fields of an object created by authentik itself, such as the default flows, stages and brand. " +
+			"The object is never created or deleted, only the configured `fields` are updated.",
+		CreateContext: resourceManagedObjectCreate,
+		ReadContext:   resourceManagedObjectRead,
+		UpdateContext: resourceManagedObjectUpdate,
+		DeleteContext: resourceManagedObjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceManagedObjectImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"model": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      helpers.EnumToDescription(slices.Sorted(maps.Keys(managedObjectModels))),
+				ValidateDiagFunc: helpers.StringInEnum(slices.Sorted(maps.Keys(managedObjectModels))),
+			},
+			"managed": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"managed", "identifier"},
+				Description:  "`managed` key of the object, only supported for sources and property mappings.",
+			},
+			"identifier": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Domain of a brand, slug of a flow or source, or name of a stage or property mapping.",
+			},
+			"fields": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Fields to update on the object. " + helpers.JSONDescription,
+				DiffSuppressFunc: helpers.DiffSuppressJSON,
+				ValidateDiagFunc: helpers.ValidateJSON,
+			},
+			"on_destroy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          managedObjectOnDestroyKeep,
+				Description:      "Whether to keep the object as it is or restore the original values of `fields` when the resource is destroyed.",
+				ValidateDiagFunc: helpers.StringInEnum([]string{managedObjectOnDestroyKeep, managedObjectOnDestroyRestore}),
+			},
+			"original": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Values of `fields` before they were first changed by Terraform, encoded as JSON.",
+			},
+		},
+	}
+}
+
+// resourceManagedObjectFind Find the object configured by `managed` or `identifier` and get its PK
+func resourceManagedObjectFind(ctx context.Context, d *schema.ResourceData, c *APIClient) (string, diag.Diagnostics) {
+	name := d.Get("model").(string)
+	model := managedObjectModels[name]
+	field, value := model.identifier, d.Get("identifier").(string)
+	if managed, ok := d.GetOk("managed"); ok {
+		if !model.managed {
+			return "", diag.Errorf("objects of type '%s' can't be looked up by their managed key", name)
+		}
+		field, value = "managed", managed.(string)
+	}
+	pk, hr, err := model.find(ctx, c, field, value)
+	if err != nil {
+		return "", helpers.HTTPToDiag(d, hr, err)
+	}
+	if pk == "" {
+		return "", diag.Errorf("no %s found with %s '%s'", name, field, value)
+	}
+	return pk, nil
+}
+
+// resourceManagedObjectPath Get the current API path of the object, empty when it doesn't exist anymore
+func resourceManagedObjectPath(ctx context.Context, d *schema.ResourceData, c *APIClient) (string, diag.Diagnostics) {
+	name, pk, _ := strings.Cut(d.Id(), ":")
+	model, ok := managedObjectModels[name]
+	if !ok || pk == "" {
+		return "", diag.Errorf("Invalid ID format, expected `model:pk`")
+	}
+	path, hr, err := model.path(ctx, c, pk)
+	if err != nil {
+		return "", helpers.HTTPToDiag(d, hr, err)
+	}
+	return path, nil
+}
+
+// resourceManagedObjectImport Import an object by `model:identifier=value` or `model:managed=value`
+func resourceManagedObjectImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	c := m.(*APIClient)
+
+	name, key, _ := strings.Cut(d.Id(), ":")
+	field, value, _ := strings.Cut(key, "=")
+	if _, ok := managedObjectModels[name]; !ok || (field != "identifier" && field != "managed") || value == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected `model:identifier=value` or `model:managed=value`", d.Id())
+	}
+	helpers.SetWrapper(d, "model", name)
+	helpers.SetWrapper(d, field, value)
+	helpers.SetWrapper(d, "on_destroy", managedObjectOnDestroyKeep)
+	pk, diags := resourceManagedObjectFind(ctx, d, c)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	d.SetId(fmt.Sprintf("%s:%s", name, pk))
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceManagedObjectOriginal Add the current values of fields which aren't tracked yet to the original values
+func resourceManagedObjectOriginal(ctx context.Context, d *schema.ResourceData, c *APIClient, path string, fields map[string]any) (map[string]any, diag.Diagnostics) {
+	original, diags := helpers.GetJSON[map[string]any](d, "original")
+	if diags != nil {
+		return nil, diags
+	}
+	if original == nil {
+		original = map[string]any{}
+	}
+	current, hr, err := c.apiGetJSON(ctx, path)
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	for key := range fields {
+		if _, ok := original[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; !ok {
+			return nil, diag.Errorf("object '%s' doesn't have a field '%s'", path, key)
+		}
+		original[key] = current[key]
+	}
+	return original, nil
+}
+
+func resourceManagedObjectCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	pk, diags := resourceManagedObjectFind(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("model").(string), pk))
+	return resourceManagedObjectUpdate(ctx, d, m)
+}
+
+func resourceManagedObjectRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	fields, diags := helpers.GetJSON[map[string]any](d, "fields")
+	if diags != nil {
+		return diags
+	}
+	path, diags := resourceManagedObjectPath(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	if path == "" {
+		d.SetId("")
+		return nil
+	}
+	res, hr, err := c.apiGetJSON(ctx, path)
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	current := map[string]any{}
+	for key := range fields {
+		current[key] = res[key]
+	}
+	return helpers.SetJSON(d, "fields", current)
+}
+
+func resourceManagedObjectUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	fields, diags := helpers.GetJSON[map[string]any](d, "fields")
+	if diags != nil {
+		return diags
+	}
+	path, diags := resourceManagedObjectPath(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	if path == "" {
+		return diag.Errorf("object '%s' doesn't exist anymore", d.Id())
+	}
+	original, diags := resourceManagedObjectOriginal(ctx, d, c, path, fields)
+	if diags != nil {
+		return diags
+	}
+
+	// Fields which aren't configured anymore are no longer tracked, and restored if configured
+	removed := map[string]any{}
+	for key, value := range original {
+		if _, ok := fields[key]; !ok {
+			removed[key] = value
+			delete(original, key)
+		}
+	}
+	diags = helpers.SetJSON(d, "original", original)
+	if diags != nil {
+		return diags
+	}
+	if len(removed) > 0 && d.Get("on_destroy").(string) == managedObjectOnDestroyRestore {
+		_, hr, err := c.apiRequestJSON(ctx, http.MethodPatch, path, removed)
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+
+	_, hr, err := c.apiRequestJSON(ctx, http.MethodPatch, path, fields)
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	return resourceManagedObjectRead(ctx, d, m)
+}
+
+func resourceManagedObjectDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+	if d.Get("on_destroy").(string) != managedObjectOnDestroyRestore {
+		return diag.Diagnostics{}
+	}
+	original, diags := helpers.GetJSON[map[string]any](d, "original")
+	if diags != nil {
+		return diags
+	}
+	if len(original) < 1 {
+		return diag.Diagnostics{}
+	}
+	path, diags := resourceManagedObjectPath(ctx, d, c)
+	if diags != nil || path == "" {
+		return diags
+	}
+	_, hr, err := c.apiRequestJSON(ctx, http.MethodPatch, path, original)
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	return diag.Diagnostics{}
+}
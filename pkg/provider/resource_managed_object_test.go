@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccResourceManagedObject(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceManagedObjectSimple(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("authentik_managed_object.flow", "id", regexp.MustCompile("^flow:")),
+					resource.TestCheckResourceAttrSet("authentik_managed_object.flow", "original"),
+				),
+			},
+			{
+				Config: testAccResourceManagedObjectSimple(rName + "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_managed_object.flow", "fields", fmt.Sprintf(`{"title":"%stest"}`, rName)),
+				),
+			},
+			{
+				ResourceName:            "authentik_managed_object.flow",
+				ImportState:             true,
+				ImportStateId:           "flow:identifier=default-authentication-flow",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"fields", "original", "on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccResourceManagedObjectSimple(title string) string {
+	return fmt.Sprintf(`
+resource "authentik_managed_object" "flow" {
+  model      = "flow"
+  identifier = "default-authentication-flow"
+  fields = jsonencode({
+    title = "%[1]s"
+  })
+  on_destroy = "restore"
+}
+`, title)
+}
+
+func TestManagedObjectEndpoint(t *testing.T) {
+	path, err := managedObjectEndpoint(managedObjectStageEndpoints, "authentik_stages_identification.identificationstage", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/stages/identification/1/", path)
+	path, err = managedObjectEndpoint(managedObjectStageEndpoints, "authentik_stages_authenticator_totp.authenticatortotpstage", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/stages/authenticator/totp/1/", path)
+	path, err = managedObjectEndpoint(managedObjectStageEndpoints, "authentik_stages_prompt.promptstage", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/stages/prompt/stages/1/", path)
+	_, err = managedObjectEndpoint(managedObjectStageEndpoints, "authentik_core.user", "1")
+	assert.Error(t, err)
+
+	path, err = managedObjectEndpoint(managedObjectSourceEndpoints, "authentik_sources_oauth.oauthsource", "github")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sources/oauth/github/", path)
+
+	path, err = managedObjectEndpoint(managedObjectPropertyMappingEndpoints, "authentik_providers_oauth2.scopemapping", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/propertymappings/provider/scope/1/", path)
+	path, err = managedObjectEndpoint(managedObjectPropertyMappingEndpoints, "authentik_enterprise_providers_google_workspace.googleworkspaceproviderpropertymapping", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/propertymappings/provider/google_workspace/1/", path)
+}
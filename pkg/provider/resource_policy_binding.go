@@ -17,7 +17,7 @@ func resourcePolicyBinding() *schema.Resource {
 		UpdateContext: resourcePolicyBindingUpdate,
 		DeleteContext: resourcePolicyBindingDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importPolicyBinding,
 		},
 		Schema: map[string]*schema.Schema{
 			"target": {
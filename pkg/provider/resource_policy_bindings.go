@@ -0,0 +1,305 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+// Gap between the order of two consecutive bindings, so bindings can be inserted in between
+const policyBindingsOrderStep = 10
+
+func resourcePolicyBindings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Customization --- Authoritative list of the policy, user and group bindings of an object. " +
+			"Bindings are evaluated in the order they are listed. " +
+			"Should not be combined with `authentik_policy_binding` for the same target.",
+		CreateContext: resourcePolicyBindingsCreate,
+		ReadContext:   resourcePolicyBindingsRead,
+		UpdateContext: resourcePolicyBindingsUpdate,
+		DeleteContext: resourcePolicyBindingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			if d.HasChange("binding") {
+				return d.SetNewComputed("binding_ids")
+			}
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"target": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the object these bindings should apply to",
+			},
+			"remove_unmanaged": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Remove bindings of the target which are not configured. When disabled, a warning is shown for these bindings instead.",
+			},
+			"binding": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Bindings of the target, in the order they are evaluated. Exactly one of `policy`, `user` or `group` must be set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy": {
+							Type:        schema.TypeString,
+							Description: "UUID of the policy",
+							Optional:    true,
+						},
+						"user": {
+							Type:        schema.TypeInt,
+							Description: "PK of the user",
+							Optional:    true,
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Description: "UUID of the group",
+							Optional:    true,
+						},
+						"negate": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  30,
+						},
+						"failure_result": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"binding_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the bindings, in the same order as `binding`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourcePolicyBindingsKey Identify a binding by what it binds, as a target can only have
+// one binding for the same policy, user or group
+func resourcePolicyBindingsKey(policy string, user int32, group string) string {
+	return fmt.Sprintf("%s/%d/%s", policy, user, group)
+}
+
+func resourcePolicyBindingsBindingToModel(target string, idx int, raw map[string]any) (api.PolicyBindingRequest, diag.Diagnostics) {
+	m := api.PolicyBindingRequest{
+		Target:        target,
+		Order:         int32(idx * policyBindingsOrderStep),
+		Negate:        new(raw["negate"].(bool)),
+		Enabled:       new(raw["enabled"].(bool)),
+		Timeout:       new(int32(raw["timeout"].(int))),
+		FailureResult: new(raw["failure_result"].(bool)),
+	}
+	configured := 0
+	if policy := raw["policy"].(string); policy != "" {
+		m.Policy = *api.NewNullableString(new(policy))
+		configured += 1
+	}
+	if user := raw["user"].(int); user != 0 {
+		m.User = *api.NewNullableInt32(new(int32(user)))
+		configured += 1
+	}
+	if group := raw["group"].(string); group != "" {
+		m.Group = *api.NewNullableString(new(group))
+		configured += 1
+	}
+	if configured != 1 {
+		return m, diag.Errorf("Exactly one of `policy`, `user` or `group` must be set for binding %d", idx)
+	}
+	return m, nil
+}
+
+func resourcePolicyBindingsModelKey(m api.PolicyBindingRequest) string {
+	return resourcePolicyBindingsKey(m.GetPolicy(), m.GetUser(), m.GetGroup())
+}
+
+func resourcePolicyBindingsBindingKey(b api.PolicyBinding) string {
+	return resourcePolicyBindingsKey(b.GetPolicy(), b.GetUser(), b.GetGroup())
+}
+
+// resourcePolicyBindingsMatches Check if an existing binding already matches the desired binding
+func resourcePolicyBindingsMatches(current api.PolicyBinding, desired api.PolicyBindingRequest) bool {
+	return resourcePolicyBindingsBindingKey(current) == resourcePolicyBindingsModelKey(desired) &&
+		current.GetOrder() == desired.Order &&
+		current.GetNegate() == desired.GetNegate() &&
+		current.GetEnabled() == desired.GetEnabled() &&
+		current.GetTimeout() == desired.GetTimeout() &&
+		current.GetFailureResult() == desired.GetFailureResult()
+}
+
+// resourcePolicyBindingsList Get all bindings of a target, sorted by their order
+func resourcePolicyBindingsList(ctx context.Context, d *schema.ResourceData, c *APIClient, target string) ([]api.PolicyBinding, diag.Diagnostics) {
+	bindings, hr, err := helpers.Paginator(ctx, c.client.PoliciesAPI.PoliciesBindingsList(ctx).Target(target), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	slices.SortStableFunc(bindings, func(a, b api.PolicyBinding) int {
+		return int(a.GetOrder()) - int(b.GetOrder())
+	})
+	return bindings, nil
+}
+
+// resourcePolicyBindingsApply Reconcile the bindings of the target with the configuration. Existing bindings
+// for the same policy, user or group are updated in place and new ones are created. Bindings which aren't
+// configured are either removed or, when `remove_unmanaged` is disabled, only removed if they
+// were previously managed by this resource.
+func resourcePolicyBindingsApply(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+	target := d.Get("target").(string)
+
+	unused, diags := resourcePolicyBindingsList(ctx, d, c, target)
+	if diags.HasError() {
+		return diags
+	}
+	for i, raw := range d.Get("binding").([]any) {
+		desired, diags := resourcePolicyBindingsBindingToModel(target, i, raw.(map[string]any))
+		if diags.HasError() {
+			return diags
+		}
+		idx := slices.IndexFunc(unused, func(b api.PolicyBinding) bool {
+			return resourcePolicyBindingsBindingKey(b) == resourcePolicyBindingsModelKey(desired)
+		})
+		if idx < 0 {
+			_, hr, err := c.client.PoliciesAPI.PoliciesBindingsCreate(ctx).PolicyBindingRequest(desired).Execute()
+			if err != nil {
+				return helpers.HTTPToDiag(d, hr, err)
+			}
+			continue
+		}
+		current := unused[idx]
+		unused = slices.Delete(unused, idx, idx+1)
+		if resourcePolicyBindingsMatches(current, desired) {
+			continue
+		}
+		_, hr, err := c.client.PoliciesAPI.PoliciesBindingsUpdate(ctx, current.Pk).PolicyBindingRequest(desired).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	pks := make([]string, len(unused))
+	for i, binding := range unused {
+		pks[i] = binding.Pk
+	}
+	for _, pk := range resourcePolicyBindingsRemoved(d, pks) {
+		hr, err := c.client.PoliciesAPI.PoliciesBindingsDestroy(ctx, pk).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+
+	d.SetId(target)
+	return resourcePolicyBindingsRead(ctx, d, m)
+}
+
+// resourcePolicyBindingsRemoved Get the bindings which aren't configured and should be removed. When
+// `remove_unmanaged` is disabled, only bindings previously managed by this resource are removed.
+func resourcePolicyBindingsRemoved(d *schema.ResourceData, unused []string) []string {
+	if d.Get("remove_unmanaged").(bool) {
+		return unused
+	}
+	// `binding_ids` is unknown during apply when the bindings changed, so use the IDs from the state
+	o, _ := d.GetChange("binding_ids")
+	previous := o.([]any)
+	removed := []string{}
+	for _, pk := range unused {
+		if slices.Contains(previous, any(pk)) {
+			removed = append(removed, pk)
+		}
+	}
+	return removed
+}
+
+func resourcePolicyBindingsCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return resourcePolicyBindingsApply(ctx, d, m)
+}
+
+func resourcePolicyBindingsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	bindings, diags := resourcePolicyBindingsList(ctx, d, c, d.Id())
+	if diags.HasError() {
+		return diags
+	}
+
+	// Imported resources don't have a value set yet, use the default
+	removeUnmanaged := true
+	if v, ok := d.GetOkExists("remove_unmanaged"); ok {
+		removeUnmanaged = v.(bool)
+	}
+	managed := map[string]bool{}
+	for _, raw := range d.Get("binding").([]any) {
+		b := raw.(map[string]any)
+		managed[resourcePolicyBindingsKey(b["policy"].(string), int32(b["user"].(int)), b["group"].(string))] = true
+	}
+
+	configured := []map[string]any{}
+	ids := []string{}
+	for _, binding := range bindings {
+		if !removeUnmanaged && !managed[resourcePolicyBindingsBindingKey(binding)] {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unmanaged binding '%s' on target '%s'", binding.Pk, d.Id()),
+				Detail:   "The binding is not configured in this resource. Set `remove_unmanaged` to remove it.",
+			})
+			continue
+		}
+		configured = append(configured, map[string]any{
+			"policy":         binding.GetPolicy(),
+			"user":           int(binding.GetUser()),
+			"group":          binding.GetGroup(),
+			"negate":         binding.GetNegate(),
+			"enabled":        binding.GetEnabled(),
+			"timeout":        int(binding.GetTimeout()),
+			"failure_result": binding.GetFailureResult(),
+		})
+		ids = append(ids, binding.Pk)
+	}
+	helpers.SetWrapper(d, "target", d.Id())
+	helpers.SetWrapper(d, "remove_unmanaged", removeUnmanaged)
+	helpers.SetWrapper(d, "binding", configured)
+	helpers.SetWrapper(d, "binding_ids", ids)
+	return diags
+}
+
+func resourcePolicyBindingsUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	return resourcePolicyBindingsApply(ctx, d, m)
+}
+
+func resourcePolicyBindingsDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+	for _, id := range helpers.CastSlice[string](d, "binding_ids") {
+		hr, err := c.client.PoliciesAPI.PoliciesBindingsDestroy(ctx, id).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return diag.Diagnostics{}
+}
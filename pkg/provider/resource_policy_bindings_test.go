@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccResourcePolicyBindings(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourcePolicyBindingsSimple(rName, `
+  binding {
+    policy = authentik_policy_dummy.name.id
+  }
+  binding {
+    group = authentik_group.name.id
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_policy_bindings.bindings", "binding.#", "2"),
+					resource.TestCheckResourceAttr("authentik_policy_bindings.bindings", "binding_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("authentik_policy_bindings.bindings", "binding.0.policy", "authentik_policy_dummy.name", "id"),
+				),
+			},
+			{
+				Config: testAccResourcePolicyBindingsSimple(rName, `
+  binding {
+    group = authentik_group.name.id
+  }
+  binding {
+    policy = authentik_policy_dummy.name.id
+    negate = true
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_policy_bindings.bindings", "binding.#", "2"),
+					resource.TestCheckResourceAttrPair("authentik_policy_bindings.bindings", "binding.0.group", "authentik_group.name", "id"),
+					resource.TestCheckResourceAttr("authentik_policy_bindings.bindings", "binding.1.negate", "true"),
+				),
+			},
+			{
+				Config: testAccResourcePolicyBindingsSimple(rName, `
+  binding {
+    group = authentik_group.name.id
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_policy_bindings.bindings", "binding.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourcePolicyBindingsRemoved(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "target",
+		Attributes: map[string]string{
+			"id":                       "target",
+			"target":                   "target",
+			"remove_unmanaged":         "false",
+			"binding.#":                "2",
+			"binding.0.policy":         "policy",
+			"binding.0.user":           "0",
+			"binding.0.group":          "",
+			"binding.0.negate":         "false",
+			"binding.0.enabled":        "true",
+			"binding.0.timeout":        "30",
+			"binding.0.failure_result": "false",
+			"binding.1.policy":         "",
+			"binding.1.user":           "0",
+			"binding.1.group":          "group",
+			"binding.1.negate":         "false",
+			"binding.1.enabled":        "true",
+			"binding.1.timeout":        "30",
+			"binding.1.failure_result": "false",
+			"binding_ids.#":            "2",
+			"binding_ids.0":            "policy-binding",
+			"binding_ids.1":            "group-binding",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"target":           "target",
+		"remove_unmanaged": false,
+		"binding": []any{
+			map[string]any{"policy": "policy"},
+		},
+	})
+
+	var removed []string
+	res := resourcePolicyBindings()
+	res.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		// The group binding was removed from the configuration, the other binding wasn't created by this resource
+		removed = resourcePolicyBindingsRemoved(d, []string{"group-binding", "unmanaged-binding"})
+		return nil
+	}
+	diff, err := res.Diff(t.Context(), state, config, nil)
+	assert.NoError(t, err)
+	_, diags := res.Apply(t.Context(), state, diff, nil)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []string{"group-binding"}, removed)
+}
+
+func testAccResourcePolicyBindingsSimple(name string, bindings string) string {
+	return fmt.Sprintf(`
+resource "authentik_policy_dummy" "name" {
+  name = "%[1]s"
+}
+
+resource "authentik_group" "name" {
+  name = "%[1]s"
+}
+
+resource "authentik_application" "name" {
+  name = "%[1]s"
+  slug = "%[1]s"
+}
+
+resource "authentik_policy_bindings" "bindings" {
+  target = authentik_application.name.uuid
+%[2]s}
+`, name, bindings)
+}
@@ -17,7 +17,7 @@ func resourcePolicyDummy() *schema.Resource {
 		UpdateContext: resourcePolicyDummyUpdate,
 		DeleteContext: resourcePolicyDummyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
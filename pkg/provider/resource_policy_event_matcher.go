@@ -17,7 +17,7 @@ func resourcePolicyEventMatcher() *schema.Resource {
 		UpdateContext: resourcePolicyEventMatcherUpdate,
 		DeleteContext: resourcePolicyEventMatcherDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourcePolicyExpiry() *schema.Resource {
 		UpdateContext: resourcePolicyExpiryUpdate,
 		DeleteContext: resourcePolicyExpiryDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
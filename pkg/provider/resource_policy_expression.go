@@ -17,7 +17,7 @@ func resourcePolicyExpression() *schema.Resource {
 		UpdateContext: resourcePolicyExpressionUpdate,
 		DeleteContext: resourcePolicyExpressionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourcePolicyGeoIP() *schema.Resource {
 		UpdateContext: resourcePolicyGeoIPUpdate,
 		DeleteContext: resourcePolicyGeoIPDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
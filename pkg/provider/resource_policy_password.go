@@ -17,7 +17,7 @@ func resourcePolicyPassword() *schema.Resource {
 		UpdateContext: resourcePolicyPasswordUpdate,
 		DeleteContext: resourcePolicyPasswordDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourcePolicyReputation() *schema.Resource {
 		UpdateContext: resourcePolicyReputationUpdate,
 		DeleteContext: resourcePolicyReputationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
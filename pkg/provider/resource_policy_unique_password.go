@@ -17,7 +17,7 @@ func resourcePolicyUniquePassword() *schema.Resource {
 		UpdateContext: resourcePolicyUniquePasswordUpdate,
 		DeleteContext: resourcePolicyUniquePasswordDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPolicyKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -18,7 +18,7 @@ func resourceProviderGoogleWorkspace() *schema.Resource {
 		UpdateContext: resourceProviderGoogleWorkspaceUpdate,
 		DeleteContext: resourceProviderGoogleWorkspaceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -101,6 +101,7 @@ func resourceProviderGoogleWorkspace() *schema.Resource {
 				Default:  100,
 				Optional: true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -197,6 +198,9 @@ func resourceProviderGoogleWorkspaceUpdate(ctx context.Context, d *schema.Resour
 
 func resourceProviderGoogleWorkspaceDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
@@ -18,7 +18,7 @@ func resourceProviderLDAP() *schema.Resource {
 		UpdateContext: resourceProviderLDAPUpdate,
 		DeleteContext: resourceProviderLDAPDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -70,6 +70,7 @@ func resourceProviderLDAP() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -150,6 +151,9 @@ func resourceProviderLDAPUpdate(ctx context.Context, d *schema.ResourceData, m a
 
 func resourceProviderLDAPDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
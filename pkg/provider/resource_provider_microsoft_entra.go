@@ -18,7 +18,7 @@ func resourceProviderMicrosoftEntra() *schema.Resource {
 		UpdateContext: resourceProviderMicrosoftEntraUpdate,
 		DeleteContext: resourceProviderMicrosoftEntraDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -103,6 +103,7 @@ func resourceProviderMicrosoftEntra() *schema.Resource {
 				Default:  100,
 				Optional: true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -199,6 +200,9 @@ func resourceProviderMicrosoftEntraUpdate(ctx context.Context, d *schema.Resourc
 
 func resourceProviderMicrosoftEntraDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
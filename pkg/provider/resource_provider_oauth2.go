@@ -10,6 +10,11 @@ import (
 	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
 )
 
+// Attributes which are only supported by newer versions of authentik
+var resourceProviderOAuth2AttributeVersions = map[string]string{
+	"logout_uri": "2025.10",
+}
+
 func resourceProviderOAuth2() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Applications --- ",
@@ -18,7 +23,7 @@ func resourceProviderOAuth2() *schema.Resource {
 		UpdateContext: resourceProviderOAuth2Update,
 		DeleteContext: resourceProviderOAuth2Delete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -168,6 +173,7 @@ func resourceProviderOAuth2() *schema.Resource {
 				},
 				Description: "JWTs issued by any of the configured providers can be used to authenticate on behalf of this provider.",
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -370,6 +376,9 @@ func resourceProviderOAuth2Update(ctx context.Context, d *schema.ResourceData, m
 
 func resourceProviderOAuth2Delete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
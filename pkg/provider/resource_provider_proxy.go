@@ -18,7 +18,7 @@ func resourceProviderProxy() *schema.Resource {
 		UpdateContext: resourceProviderProxyUpdate,
 		DeleteContext: resourceProviderProxyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -134,6 +134,7 @@ func resourceProviderProxy() *schema.Resource {
 				},
 				Description: "JWTs issued by any of the configured providers can be used to authenticate on behalf of this provider.",
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -255,6 +256,9 @@ func resourceProviderProxyUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceProviderProxyDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
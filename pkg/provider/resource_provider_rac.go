@@ -18,7 +18,7 @@ func resourceProviderRAC() *schema.Resource {
 		UpdateContext: resourceProviderRACUpdate,
 		DeleteContext: resourceProviderRACDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -56,6 +56,7 @@ func resourceProviderRAC() *schema.Resource {
 				Description:      helpers.RelativeDurationDescription,
 				ValidateDiagFunc: helpers.ValidateRelativeDuration,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -134,6 +135,9 @@ func resourceProviderRACUpdate(ctx context.Context, d *schema.ResourceData, m an
 
 func resourceProviderRACDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
@@ -18,7 +18,7 @@ func resourceProviderRadius() *schema.Resource {
 		UpdateContext: resourceProviderRadiusUpdate,
 		DeleteContext: resourceProviderRadiusDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -59,6 +59,7 @@ func resourceProviderRadius() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -136,6 +137,9 @@ func resourceProviderRadiusUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceProviderRadiusDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
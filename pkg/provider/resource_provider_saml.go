@@ -18,7 +18,7 @@ func resourceProviderSAML() *schema.Resource {
 		UpdateContext: resourceProviderSAMLUpdate,
 		DeleteContext: resourceProviderSAMLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -190,6 +190,7 @@ func resourceProviderSAML() *schema.Resource {
 				Description:      helpers.EnumToDescription(api.AllowedSAMLLogoutMethodsEnumValues),
 				ValidateDiagFunc: helpers.StringInEnum(api.AllowedSAMLLogoutMethodsEnumValues),
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -312,6 +313,9 @@ func resourceProviderSAMLUpdate(ctx context.Context, d *schema.ResourceData, m a
 
 func resourceProviderSAMLDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
@@ -18,7 +18,7 @@ func resourceProviderSCIM() *schema.Resource {
 		UpdateContext: resourceProviderSCIMUpdate,
 		DeleteContext: resourceProviderSCIMDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -110,6 +110,7 @@ func resourceProviderSCIM() *schema.Resource {
 				Default:  100,
 				Optional: true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -213,6 +214,9 @@ func resourceProviderSCIMUpdate(ctx context.Context, d *schema.ResourceData, m a
 
 func resourceProviderSCIMDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
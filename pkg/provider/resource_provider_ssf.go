@@ -18,7 +18,7 @@ func resourceProviderSSF() *schema.Resource {
 		UpdateContext: resourceProviderSSFUpdate,
 		DeleteContext: resourceProviderSSFDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -49,6 +49,7 @@ func resourceProviderSSF() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -124,6 +125,9 @@ func resourceProviderSSFUpdate(ctx context.Context, d *schema.ResourceData, m an
 
 func resourceProviderSSFDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
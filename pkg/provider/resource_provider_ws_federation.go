@@ -18,7 +18,7 @@ func resourceProviderWSFederation() *schema.Resource {
 		UpdateContext: resourceProviderWSFederationUpdate,
 		DeleteContext: resourceProviderWSFederationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importProviderKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -114,6 +114,7 @@ func resourceProviderWSFederation() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -213,6 +214,9 @@ func resourceProviderWSFederationUpdate(ctx context.Context, d *schema.ResourceD
 
 func resourceProviderWSFederationDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "provider", d.Get("name").(string)); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
@@ -20,7 +20,7 @@ func resourceRBACRoleObjectPermission() *schema.Resource {
 		// UpdateContext: resourceRBACRoleObjectPermissionUpdate,
 		DeleteContext: resourceRBACRoleObjectPermissionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importRBACRoleObjectPermission,
 		},
 		Schema: map[string]*schema.Schema{
 			"role": {
@@ -90,8 +90,8 @@ func resourceRBACRoleObjectPermissionRead(ctx context.Context, d *schema.Resourc
 
 	_, object := d.GetOk("object_id")
 	if object {
-		perms, hr, err := helpers.Paginator(c.client.RbacAPI.RbacPermissionsRolesList(ctx).Uuid(d.Get("role").(string)), helpers.PaginatorOptions{
-			PageSize: 50,
+		perms, hr, err := helpers.Paginator(ctx, c.client.RbacAPI.RbacPermissionsRolesList(ctx).Uuid(d.Get("role").(string)), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
 		})
 		if err != nil {
 			return helpers.HTTPToDiag(d, hr, err)
@@ -104,8 +104,8 @@ func resourceRBACRoleObjectPermissionRead(ctx context.Context, d *schema.Resourc
 			}
 		}
 	} else {
-		perms, hr, err := helpers.Paginator(c.client.RbacAPI.RbacPermissionsList(ctx).Role(d.Get("role").(string)), helpers.PaginatorOptions{
-			PageSize: 50,
+		perms, hr, err := helpers.Paginator(ctx, c.client.RbacAPI.RbacPermissionsList(ctx).Role(d.Get("role").(string)), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
 		})
 		if err != nil {
 			return helpers.HTTPToDiag(d, hr, err)
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+func resourceRBACRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		Description: "RBAC --- Assign a role to a single user or group without managing their other roles. " +
+			"Don't combine with `roles` on `authentik_user` or `authentik_group` for the same user or group.",
+		CreateContext: resourceRBACRoleAssignmentCreate,
+		ReadContext:   resourceRBACRoleAssignmentRead,
+		DeleteContext: resourceRBACRoleAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the role.",
+			},
+			"user": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user", "group"},
+				Description:  "The ID of the user to assign the role to.",
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the group to assign the role to.",
+			},
+		},
+	}
+}
+
+// lockRoleTarget Serialize changes to the roles of a user or group, as assignments are
+// applied by reading and updating all roles of the user or group
+func (c *APIClient) lockRoleTarget(d *schema.ResourceData) func() {
+	key := fmt.Sprintf("group/%s", d.Get("group").(string))
+	if user, ok := d.GetOk("user"); ok {
+		key = fmt.Sprintf("user/%d", user.(int))
+	}
+	mu, _ := c.roleTargetLocks.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// resourceRBACRoleAssignmentRoles Get the current roles of the user or group
+func resourceRBACRoleAssignmentRoles(ctx context.Context, d *schema.ResourceData, c *APIClient) ([]string, diag.Diagnostics) {
+	if user, ok := d.GetOk("user"); ok {
+		res, hr, err := c.client.CoreAPI.CoreUsersRetrieve(ctx, int32(user.(int))).Execute()
+		if err != nil {
+			return nil, helpers.HTTPToDiag(d, hr, err)
+		}
+		return res.Roles, nil
+	}
+	res, hr, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, d.Get("group").(string)).IncludeUsers(false).Execute()
+	if err != nil {
+		return nil, helpers.HTTPToDiag(d, hr, err)
+	}
+	return res.Roles, nil
+}
+
+// resourceRBACRoleAssignmentSetRoles Update the roles of a user or group without changing any other field
+func resourceRBACRoleAssignmentSetRoles(ctx context.Context, d *schema.ResourceData, c *APIClient, roles []string) diag.Diagnostics {
+	if user, ok := d.GetOk("user"); ok {
+		_, hr, err := c.client.CoreAPI.CoreUsersPartialUpdate(ctx, int32(user.(int))).PatchedUserRequest(api.PatchedUserRequest{
+			Roles: roles,
+		}).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		return nil
+	}
+	_, hr, err := c.client.CoreAPI.CoreGroupsPartialUpdate(ctx, d.Get("group").(string)).PatchedGroupRequest(api.PatchedGroupRequest{
+		Roles: roles,
+	}).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	return nil
+}
+
+func resourceRBACRoleAssignmentCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	role := d.Get("role").(string)
+	unlock := c.lockRoleTarget(d)
+	defer unlock()
+	roles, diags := resourceRBACRoleAssignmentRoles(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	if !slices.Contains(roles, role) {
+		if diags := resourceRBACRoleAssignmentSetRoles(ctx, d, c, append(roles, role)); diags != nil {
+			return diags
+		}
+	}
+	if user, ok := d.GetOk("user"); ok {
+		d.SetId(fmt.Sprintf("%s/user/%d", role, user.(int)))
+	} else {
+		d.SetId(fmt.Sprintf("%s/group/%s", role, d.Get("group").(string)))
+	}
+	return resourceRBACRoleAssignmentRead(ctx, d, m)
+}
+
+func resourceRBACRoleAssignmentRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || (parts[1] != "user" && parts[1] != "group") {
+		return diag.Errorf("Invalid ID format, expected `role/user/user-id` or `role/group/group-uuid`")
+	}
+	role := parts[0]
+	helpers.SetWrapper(d, "role", role)
+	if parts[1] == "user" {
+		pk, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		helpers.SetWrapper(d, "user", pk)
+	} else {
+		helpers.SetWrapper(d, "group", parts[2])
+	}
+	roles, diags := resourceRBACRoleAssignmentRoles(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	if !slices.Contains(roles, role) {
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceRBACRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	role := d.Get("role").(string)
+	unlock := c.lockRoleTarget(d)
+	defer unlock()
+	roles, diags := resourceRBACRoleAssignmentRoles(ctx, d, c)
+	if diags != nil {
+		return diags
+	}
+	if !slices.Contains(roles, role) {
+		return diag.Diagnostics{}
+	}
+	remaining := slices.DeleteFunc(roles, func(r string) bool {
+		return r == role
+	})
+	return resourceRBACRoleAssignmentSetRoles(ctx, d, c, remaining)
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRBACRoleAssignment(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRBACRoleAssignment(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("authentik_rbac_role_assignment.user", "user", "authentik_user.user", "id"),
+					resource.TestCheckResourceAttrPair("authentik_rbac_role_assignment.group", "group", "authentik_group.group", "id"),
+				),
+			},
+			{
+				// Updating the user keeps the roles assigned by other resources
+				Config: testAccResourceRBACRoleAssignment(rName, rName+"-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.user", "name", rName+"-updated"),
+					resource.TestCheckResourceAttr("authentik_user.user", "roles.#", "1"),
+					resource.TestCheckResourceAttrPair("authentik_user.user", "roles.0", "authentik_rbac_role.role", "id"),
+				),
+			},
+			{
+				ResourceName:      "authentik_rbac_role_assignment.group",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceRBACRoleAssignment(name string, userName string) string {
+	return fmt.Sprintf(`
+resource "authentik_rbac_role" "role" {
+  name = "%[1]s"
+}
+resource "authentik_user" "user" {
+  username = "%[1]s"
+  name = "%[2]s"
+}
+resource "authentik_group" "group" {
+  name = "%[1]s"
+}
+resource "authentik_rbac_role_assignment" "user" {
+  role = authentik_rbac_role.role.id
+  user = authentik_user.user.id
+}
+resource "authentik_rbac_role_assignment" "group" {
+  role  = authentik_rbac_role.role.id
+  group = authentik_group.group.id
+}
+`, name, userName)
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
+)
+
+func resourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Directory --- Create a service account together with its token, and optionally a group, in one step. " +
+			"Destroying the resource deletes the user, its token and the group created for it.",
+		CreateContext: resourceServiceAccountCreate,
+		ReadContext:   resourceServiceAccountRead,
+		UpdateContext: resourceServiceAccountUpdate,
+		DeleteContext: resourceServiceAccountDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			// The group was deleted outside of Terraform, so it's created again
+			if d.Id() != "" && d.Get("create_group").(bool) && d.Get("group").(string) == "" {
+				return d.SetNewComputed("group")
+			}
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceAccountImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Username of the service account.",
+			},
+			"create_group": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Create a group with the same name as the service account, which the service account is a member of. The group is created again when it was deleted outside of Terraform.",
+			},
+			"expiring": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the token of the service account expires.",
+			},
+			"expires": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "When the token of the service account expires, in RFC3339 format. Defaults to the token duration configured in authentik.",
+			},
+			// Computed
+			"user": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the service account user.",
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the group created for the service account.",
+			},
+			"token_identifier": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the token of the service account.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Computed:    true,
+				Description: "Token of the service account.",
+			},
+		},
+	}
+}
+
+func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	req := api.UserServiceAccountRequest{
+		Name:        d.Get("name").(string),
+		CreateGroup: new(d.Get("create_group").(bool)),
+		Expiring:    new(d.Get("expiring").(bool)),
+	}
+	if l, ok := d.Get("expires").(string); ok && l != "" {
+		t, err := time.Parse(time.RFC3339, l)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		req.Expires = &t
+	}
+	res, hr, err := c.client.CoreAPI.CoreUsersServiceAccountCreate(ctx).UserServiceAccountRequest(req).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+
+	d.SetId(strconv.Itoa(int(res.UserPk)))
+	helpers.SetWrapper(d, "token", res.Token)
+	if res.GroupPk != nil {
+		helpers.SetWrapper(d, "group", *res.GroupPk)
+	}
+
+	// The token's identifier isn't returned, so find the app password token of the new user
+	identifier, diags := resourceServiceAccountFindToken(ctx, d, c, res.Username)
+	if diags != nil {
+		return diags
+	}
+	if identifier == "" {
+		return diag.Errorf("Token of service account '%s' not found", res.Username)
+	}
+	helpers.SetWrapper(d, "token_identifier", identifier)
+	return resourceServiceAccountRead(ctx, d, m)
+}
+
+// resourceServiceAccountFindToken Get the identifier of the app password token of the service account,
+// or an empty string when it doesn't have one
+func resourceServiceAccountFindToken(ctx context.Context, d *schema.ResourceData, c *APIClient, username string) (string, diag.Diagnostics) {
+	tokens, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreTokensList(ctx).UserUsername(username), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return "", helpers.HTTPToDiag(d, hr, err)
+	}
+	for _, t := range tokens {
+		if t.GetIntent() == api.INTENTENUM_APP_PASSWORD {
+			return t.Identifier, nil
+		}
+	}
+	return "", nil
+}
+
+// resourceServiceAccountImport Import a service account by the ID of its user, together with its token
+// and the group named after it
+func resourceServiceAccountImport(ctx context.Context, d *schema.ResourceData, m any) ([]*schema.ResourceData, error) {
+	c := m.(*APIClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID %q, expected the ID of the user", d.Id())
+	}
+	user, _, err := c.client.CoreAPI.CoreUsersRetrieve(ctx, int32(id)).Execute()
+	if err != nil {
+		return nil, err
+	}
+	identifier, diags := resourceServiceAccountFindToken(ctx, d, c, user.Username)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	helpers.SetWrapper(d, "token_identifier", identifier)
+	groups, _, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreGroupsList(ctx).Name(user.Username).IncludeUsers(false), helpers.PaginatorOptions{
+		PageSize: c.pageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	helpers.SetWrapper(d, "create_group", false)
+	for _, g := range groups {
+		if g.Name == user.Username && slices.Contains(g.Users, user.Pk) {
+			helpers.SetWrapper(d, "create_group", true)
+			helpers.SetWrapper(d, "group", g.Pk)
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 32)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	res, hr, err := c.client.CoreAPI.CoreUsersRetrieve(ctx, int32(id)).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	helpers.SetWrapper(d, "name", res.Username)
+	helpers.SetWrapper(d, "user", int(res.Pk))
+	if group := d.Get("group").(string); group != "" {
+		_, hr, err := c.client.CoreAPI.CoreGroupsRetrieve(ctx, group).IncludeUsers(false).Execute()
+		if err != nil && hr != nil && hr.StatusCode == http.StatusNotFound {
+			helpers.SetWrapper(d, "group", "")
+		} else if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+
+	identifier := d.Get("token_identifier").(string)
+	if identifier == "" {
+		return nil
+	}
+	token, hr, err := c.client.CoreAPI.CoreTokensRetrieve(ctx, identifier).Execute()
+	if err != nil {
+		if hr != nil && hr.StatusCode == http.StatusNotFound {
+			// Token was deleted, so the service account has to be created again to get a new one
+			d.SetId("")
+			return nil
+		}
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	helpers.SetWrapper(d, "expiring", token.GetExpiring())
+	if token.Expires.IsSet() && token.Expires.Get() != nil {
+		helpers.SetWrapper(d, "expires", token.Expires.Get().Format(time.RFC3339))
+	}
+	key, hr, err := c.client.CoreAPI.CoreTokensViewKeyRetrieve(ctx, identifier).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	helpers.SetWrapper(d, "token", key.Key)
+	return nil
+}
+
+func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	if d.Get("create_group").(bool) && d.Get("group").(string) == "" {
+		id, err := strconv.ParseInt(d.Id(), 10, 32)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		res, hr, err := c.client.CoreAPI.CoreGroupsCreate(ctx).GroupRequest(api.GroupRequest{
+			Name:  d.Get("name").(string),
+			Users: []int32{int32(id)},
+		}).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		helpers.SetWrapper(d, "group", res.Pk)
+	}
+	if d.HasChanges("expiring", "expires") {
+		identifier := d.Get("token_identifier").(string)
+		if identifier == "" {
+			return diag.Errorf("token of service account '%s' not found", d.Get("name").(string))
+		}
+		req := api.PatchedTokenRequest{
+			Expiring: new(d.Get("expiring").(bool)),
+		}
+		if l, ok := d.Get("expires").(string); ok && l != "" {
+			t, err := time.Parse(time.RFC3339, l)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			req.Expires.Set(&t)
+		}
+		_, hr, err := c.client.CoreAPI.CoreTokensPartialUpdate(ctx, identifier).PatchedTokenRequest(req).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return resourceServiceAccountRead(ctx, d, m)
+}
+
+func resourceServiceAccountDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	c := m.(*APIClient)
+
+	id, err := strconv.ParseInt(d.Id(), 10, 32)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hr, err := c.client.CoreAPI.CoreUsersDestroy(ctx, int32(id)).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	if group := d.Get("group").(string); group != "" {
+		hr, err := c.client.CoreAPI.CoreGroupsDestroy(ctx, group).Execute()
+		if err != nil && (hr == nil || hr.StatusCode != 404) {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+	}
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceServiceAccount(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceServiceAccount(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_service_account.sa", "name", rName),
+					resource.TestCheckResourceAttrSet("authentik_service_account.sa", "group"),
+					resource.TestCheckResourceAttrSet("authentik_service_account.sa", "token"),
+				),
+			},
+			{
+				Config: testAccResourceServiceAccount(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_service_account.sa", "expiring", "false"),
+				),
+			},
+			{
+				ResourceName:      "authentik_service_account.sa",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceServiceAccount(name string, expiring bool) string {
+	return fmt.Sprintf(`
+resource "authentik_service_account" "sa" {
+  name         = "%[1]s"
+  create_group = true
+  expiring     = %[2]t
+}
+`, name, expiring)
+}
@@ -17,7 +17,7 @@ func resourceSourceKerberos() *schema.Resource {
 		UpdateContext: resourceSourceKerberosUpdate,
 		DeleteContext: resourceSourceKerberosDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceSourceLDAP() *schema.Resource {
 		UpdateContext: resourceSourceLDAPUpdate,
 		DeleteContext: resourceSourceLDAPDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceSourceOAuth() *schema.Resource {
 		UpdateContext: resourceSourceOAuthUpdate,
 		DeleteContext: resourceSourceOAuthDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
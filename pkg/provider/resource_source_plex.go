@@ -17,7 +17,7 @@ func resourceSourcePlex() *schema.Resource {
 		UpdateContext: resourceSourcePlexUpdate,
 		DeleteContext: resourceSourcePlexDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceSourceSAML() *schema.Resource {
 		UpdateContext: resourceSourceSAMLUpdate,
 		DeleteContext: resourceSourceSAMLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
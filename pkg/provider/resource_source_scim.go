@@ -17,7 +17,7 @@ func resourceSourceSCIM() *schema.Resource {
 		UpdateContext: resourceSourceSCIMUpdate,
 		DeleteContext: resourceSourceSCIMDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
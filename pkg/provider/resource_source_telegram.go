@@ -17,7 +17,7 @@ func resourceSourceTelegram() *schema.Resource {
 		UpdateContext: resourceSourceTelegramUpdate,
 		DeleteContext: resourceSourceTelegramDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importSourceKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
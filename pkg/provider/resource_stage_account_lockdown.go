@@ -17,7 +17,7 @@ func resourceStageAccountLockdown() *schema.Resource {
 		UpdateContext: resourceStageAccountLockdownUpdate,
 		DeleteContext: resourceStageAccountLockdownDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
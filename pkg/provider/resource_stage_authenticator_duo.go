@@ -17,7 +17,7 @@ func resourceStageAuthenticatorDuo() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorDuoUpdate,
 		DeleteContext: resourceStageAuthenticatorDuoDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageAuthenticatorEmail() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorEmailUpdate,
 		DeleteContext: resourceStageAuthenticatorEmailDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
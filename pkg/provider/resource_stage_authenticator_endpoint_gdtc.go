@@ -17,7 +17,7 @@ func resourceStageAuthenticatorEndpointGDTC() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorEndpointGDTCUpdate,
 		DeleteContext: resourceStageAuthenticatorEndpointGDTCDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
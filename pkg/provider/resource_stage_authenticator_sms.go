@@ -17,7 +17,7 @@ func resourceStageAuthenticatorSms() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorSmsUpdate,
 		DeleteContext: resourceStageAuthenticatorSmsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageAuthenticatorStatic() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorStaticUpdate,
 		DeleteContext: resourceStageAuthenticatorStaticDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
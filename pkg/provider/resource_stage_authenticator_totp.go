@@ -17,7 +17,7 @@ func resourceStageAuthenticatorTOTP() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorTOTPUpdate,
 		DeleteContext: resourceStageAuthenticatorTOTPDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageAuthenticatorValidate() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorValidateUpdate,
 		DeleteContext: resourceStageAuthenticatorValidateDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageAuthenticatorWebAuthn() *schema.Resource {
 		UpdateContext: resourceStageAuthenticatorWebAuthnUpdate,
 		DeleteContext: resourceStageAuthenticatorWebAuthnDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageCaptcha() *schema.Resource {
 		UpdateContext: resourceStageCaptchaUpdate,
 		DeleteContext: resourceStageCaptchaDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
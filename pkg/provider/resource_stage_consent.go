@@ -17,7 +17,7 @@ func resourceStageConsent() *schema.Resource {
 		UpdateContext: resourceStageConsentUpdate,
 		DeleteContext: resourceStageConsentDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageDeny() *schema.Resource {
 		UpdateContext: resourceStageDenyUpdate,
 		DeleteContext: resourceStageDenyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageDummy() *schema.Resource {
 		UpdateContext: resourceStageDummyUpdate,
 		DeleteContext: resourceStageDummyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageEmail() *schema.Resource {
 		UpdateContext: resourceStageEmailUpdate,
 		DeleteContext: resourceStageEmailDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
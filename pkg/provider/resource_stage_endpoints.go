@@ -17,7 +17,7 @@ func resourceStageEndpoints() *schema.Resource {
 		UpdateContext: resourceStageEndpointsUpdate,
 		DeleteContext: resourceStageEndpointsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageIdentification() *schema.Resource {
 		UpdateContext: resourceStageIdentificationUpdate,
 		DeleteContext: resourceStageIdentificationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
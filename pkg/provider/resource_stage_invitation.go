@@ -17,7 +17,7 @@ func resourceStageInvitation() *schema.Resource {
 		UpdateContext: resourceStageInvitationUpdate,
 		DeleteContext: resourceStageInvitationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageMutualTLS() *schema.Resource {
 		UpdateContext: resourceStageMutualTLSUpdate,
 		DeleteContext: resourceStageMutualTLSDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
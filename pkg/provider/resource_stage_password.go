@@ -17,7 +17,7 @@ func resourceStagePassword() *schema.Resource {
 		UpdateContext: resourceStagePasswordUpdate,
 		DeleteContext: resourceStagePasswordDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStagePrompt() *schema.Resource {
 		UpdateContext: resourceStagePromptUpdate,
 		DeleteContext: resourceStagePromptDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
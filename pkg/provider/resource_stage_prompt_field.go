@@ -17,7 +17,7 @@ func resourceStagePromptField() *schema.Resource {
 		UpdateContext: resourceStagePromptFieldUpdate,
 		DeleteContext: resourceStagePromptFieldDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importPromptFieldKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
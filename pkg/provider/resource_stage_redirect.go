@@ -17,7 +17,7 @@ func resourceStageRedirect() *schema.Resource {
 		UpdateContext: resourceStageRedirectUpdate,
 		DeleteContext: resourceStageRedirectDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageSource() *schema.Resource {
 		UpdateContext: resourceStageSourceUpdate,
 		DeleteContext: resourceStageSourceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
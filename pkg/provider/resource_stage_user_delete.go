@@ -17,7 +17,7 @@ func resourceStageUserDelete() *schema.Resource {
 		UpdateContext: resourceStageUserDeleteUpdate,
 		DeleteContext: resourceStageUserDeleteDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
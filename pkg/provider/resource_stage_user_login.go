@@ -17,7 +17,7 @@ func resourceStageUserLogin() *schema.Resource {
 		UpdateContext: resourceStageUserLoginUpdate,
 		DeleteContext: resourceStageUserLoginDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
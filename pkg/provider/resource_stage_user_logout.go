@@ -17,7 +17,7 @@ func resourceStageUserLogout() *schema.Resource {
 		UpdateContext: resourceStageUserLogoutUpdate,
 		DeleteContext: resourceStageUserLogoutDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -17,7 +17,7 @@ func resourceStageUserWrite() *schema.Resource {
 		UpdateContext: resourceStageUserWriteUpdate,
 		DeleteContext: resourceStageUserWriteDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importStageKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
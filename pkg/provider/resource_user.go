@@ -2,14 +2,22 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	api "goauthentik.io/api/v3"
 	"goauthentik.io/terraform-provider-authentik/pkg/helpers"
 )
 
+const (
+	userOnDestroyDelete     = "delete"
+	userOnDestroyDeactivate = "deactivate"
+)
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Directory --- ",
@@ -17,8 +25,16 @@ func resourceUser() *schema.Resource {
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+			// Set the password from `password_wo` again when it was changed outside of Terraform
+			config := d.GetRawConfig()
+			if d.Get("password_changed_externally").(bool) && !config.IsNull() && !config.GetAttr("password_version").IsNull() {
+				return d.SetNew("password_changed_externally", false)
+			}
+			return nil
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey(importUserKeys),
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -38,10 +54,36 @@ func resourceUser() *schema.Resource {
 				ValidateDiagFunc: helpers.StringInEnum(api.AllowedUserTypeEnumEnumValues),
 			},
 			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password_wo"},
+				Description:   `Optionally set the user's password. Changing the password in authentik will not trigger an update here.`,
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+				RequiredWith:  []string{"password_version"},
+				Description:   "Password of the user, which is never stored in the state. The password is set when the user is created and whenever `password_version` changes. Requires Terraform 1.11 or later.",
+			},
+			"password_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Change this value to set the password from `password_wo` again. " +
+					"When the password is changed outside of Terraform, the next plan sets it again, see `password_changed_externally`.",
+			},
+			"password_change_date": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: `Optionally set the user's password. Changing the password in authentik will not trigger an update here.`,
+				Computed:    true,
+				Description: "When the password of the user was last changed.",
+			},
+			"password_changed_externally": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the password was changed outside of Terraform since it was last set by Terraform.",
 			},
 			"is_active": {
 				Type:     schema.TypeBool,
@@ -61,14 +103,17 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Computed: true,
+				Description: "Authoritative list of the groups of the user, groups not listed here are removed. " +
+					"Leave unset when memberships are managed with `authentik_group_membership` or `authentik_group_members`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			"roles": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Leave unset when roles are assigned with `authentik_rbac_role_assignment`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -81,6 +126,35 @@ func resourceUser() *schema.Resource {
 				DiffSuppressFunc: helpers.DiffSuppressJSON,
 				ValidateDiagFunc: helpers.ValidateJSON,
 			},
+			"attributes_mode":        attributesModeSchema(),
+			"managed_attribute_keys": managedAttributeKeysSchema(),
+			"adopt_existing":         adoptExistingSchema("username"),
+			"deletion_protection":    deletionProtectionSchema(),
+			"on_destroy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  userOnDestroyDelete,
+				Description: "Whether to delete the user or only deactivate them when the resource is destroyed. Deactivated users keep their history and devices. " +
+					"Creating a user with the same username again requires `adopt_existing`.",
+				ValidateDiagFunc: helpers.StringInEnum([]string{userOnDestroyDelete, userOnDestroyDeactivate}),
+			},
+			"deactivate_revoke_sessions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "End all sessions of the user when they're deactivated by `on_destroy`.",
+			},
+			"deactivate_revoke_tokens": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Delete all tokens of the user when they're deactivated by `on_destroy`.",
+			},
+			"deactivate_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path the user is moved to when they're deactivated by `on_destroy`.",
+			},
 		},
 	}
 }
@@ -101,28 +175,86 @@ func resourceUserSchemaToModel(d *schema.ResourceData) (*api.UserRequest, diag.D
 	return &m, err
 }
 
-func resourceUserSetPassword(d *schema.ResourceData, c *APIClient, ctx context.Context) diag.Diagnostics {
-	password, ok := d.Get("password").(string)
-	if !ok || password == "" {
-		return nil
+// resourceUserPassword Get the password to set, either from `password` when the user is created
+// or from `password_wo` when the user is created, `password_version` changes or the password was changed
+// outside of Terraform
+func resourceUserPassword(d *schema.ResourceData) (string, diag.Diagnostics) {
+	if password, ok := d.Get("password").(string); ok && password != "" {
+		if !d.IsNewResource() {
+			return "", nil
+		}
+		return password, nil
 	}
-	if !d.IsNewResource() {
-		return nil
+	if !d.IsNewResource() && !d.HasChanges("password_version", "password_changed_externally") {
+		return "", nil
+	}
+	wo, diags := d.GetRawConfigAt(cty.GetAttrPath("password_wo"))
+	if diags.HasError() {
+		return "", diags
+	}
+	if wo.IsNull() || !wo.IsKnown() || !wo.Type().Equals(cty.String) {
+		return "", nil
 	}
+	return wo.AsString(), nil
+}
+
+func resourceUserSetPassword(d *schema.ResourceData, c *APIClient, ctx context.Context) diag.Diagnostics {
 	uid, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	hr, err := c.client.CoreAPI.CoreUsersSetPasswordCreate(ctx, int32(uid)).UserPasswordSetRequest(api.UserPasswordSetRequest{
-		Password: password,
-	}).Execute()
-	if err != nil {
-		return helpers.HTTPToDiag(d, hr, err)
+	password, diags := resourceUserPassword(d)
+	if diags != nil {
+		return diags
+	}
+	if password != "" {
+		hr, err := c.client.CoreAPI.CoreUsersSetPasswordCreate(ctx, int32(uid)).UserPasswordSetRequest(api.UserPasswordSetRequest{
+			Password: password,
+		}).Execute()
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		// Changed by Terraform, so not considered drift
+		helpers.SetWrapper(d, "password_change_date", "")
+		helpers.SetWrapper(d, "password_changed_externally", false)
 	}
-	helpers.SetWrapper(d, "password", password)
 	return nil
 }
 
+// resourceUserCheckLockout Prevent deleting or deactivating the user the provider authenticates as or the
+// last active superuser, and removing the last superuser from all superuser groups
+func resourceUserCheckLockout(ctx context.Context, d *schema.ResourceData, c *APIClient, deleting bool) diag.Diagnostics {
+	state, diags := c.lockoutCurrentState(ctx, d)
+	if diags != nil || state == nil {
+		return diags
+	}
+	id, err := strconv.ParseInt(d.Id(), 10, 32)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pk := int32(id)
+	username := d.Get("username").(string)
+	if deleting {
+		return state.check(fmt.Sprintf("Deleting user '%s'", username), []int32{pk})
+	}
+	if !d.Get("is_active").(bool) {
+		return state.check(fmt.Sprintf("Deactivating user '%s'", username), []int32{pk})
+	}
+	if !state.isSuperuser(pk) {
+		return nil
+	}
+	groups, diags := c.superuserGroups(ctx, d)
+	if diags != nil {
+		return diags
+	}
+	for _, group := range helpers.CastSlice[string](d, "groups") {
+		if _, ok := groups[group]; ok {
+			return nil
+		}
+	}
+	return state.check(fmt.Sprintf("Removing user '%s' from all superuser groups", username), []int32{pk})
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
 
@@ -132,11 +264,21 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m any) diag
 	}
 
 	res, hr, err := c.client.CoreAPI.CoreUsersCreate(ctx).UserRequest(*app).Execute()
+	if err != nil && c.shouldAdopt(d) && isUniqueConflict(hr, "username") {
+		id, err := importUserByUsername(ctx, c, app.Username)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return adoptExisting(d, "user", app.Username, id, func() diag.Diagnostics {
+			return resourceUserUpdate(ctx, d, m)
+		})
+	}
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
 
 	d.SetId(strconv.Itoa(int(res.Pk)))
+	setManagedAttributeKeys(d, app.Attributes)
 
 	diags = resourceUserSetPassword(d, c, ctx)
 	if diags != nil {
@@ -164,6 +306,10 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 	helpers.SetWrapper(d, "email", res.Email)
 	helpers.SetWrapper(d, "is_active", res.IsActive)
 	helpers.SetWrapper(d, "path", res.Path)
+	changed := res.GetPasswordChangeDate().Format(time.RFC3339Nano)
+	last := d.Get("password_change_date").(string)
+	helpers.SetWrapper(d, "password_changed_externally", d.Get("password_changed_externally").(bool) || (last != "" && last != changed))
+	helpers.SetWrapper(d, "password_change_date", changed)
 	helpers.SetWrapper(d, "groups", helpers.ListConsistentMerge(
 		helpers.CastSlice[string](d, "groups"),
 		res.Groups,
@@ -172,7 +318,7 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m any) diag.D
 		helpers.CastSlice[string](d, "roles"),
 		res.Roles,
 	))
-	return helpers.SetJSON(d, "attributes", res.Attributes)
+	return setStateAttributes(d, res.Attributes)
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
@@ -182,11 +328,43 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag
 	if di != nil {
 		return di
 	}
+	if d.HasChanges("is_active", "groups") {
+		if diags := resourceUserCheckLockout(ctx, d, c, false); diags != nil {
+			return diags
+		}
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	res, hr, err := c.client.CoreAPI.CoreUsersUpdate(ctx, int32(id)).UserRequest(*app).Execute()
+	app.Attributes, di = resourceAttributes(d, func() (map[string]any, diag.Diagnostics) {
+		res, hr, err := c.client.CoreAPI.CoreUsersRetrieve(ctx, int32(id)).Execute()
+		if err != nil {
+			return nil, helpers.HTTPToDiag(d, hr, err)
+		}
+		return res.Attributes, nil
+	})
+	if di != nil {
+		return di
+	}
+	// Groups and roles which aren't configured are left as they are, for example when an existing user
+	// is adopted or when they're managed with other resources
+	req := api.PatchedUserRequest{
+		Name:       new(app.Name),
+		Username:   new(app.Username),
+		Type:       app.Type,
+		IsActive:   app.IsActive,
+		Path:       app.Path,
+		Email:      app.Email,
+		Attributes: app.Attributes,
+	}
+	if !d.GetRawConfig().GetAttr("groups").IsNull() {
+		req.Groups = app.Groups
+	}
+	if !d.GetRawConfig().GetAttr("roles").IsNull() {
+		req.Roles = app.Roles
+	}
+	res, hr, err := c.client.CoreAPI.CoreUsersPartialUpdate(ctx, int32(id)).PatchedUserRequest(req).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
@@ -202,13 +380,65 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m any) diag
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
 	c := m.(*APIClient)
+	if diags := c.checkDeletionProtection(d, "user", d.Get("username").(string)); diags != nil {
+		return diags
+	}
+	if diags := resourceUserCheckLockout(ctx, d, c, true); diags != nil {
+		return diags
+	}
 	id, err := strconv.ParseInt(d.Id(), 10, 32)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.Get("on_destroy").(string) == userOnDestroyDeactivate {
+		return resourceUserDeactivate(ctx, d, c, int32(id))
+	}
 	hr, err := c.client.CoreAPI.CoreUsersDestroy(ctx, int32(id)).Execute()
 	if err != nil {
 		return helpers.HTTPToDiag(d, hr, err)
 	}
 	return diag.Diagnostics{}
 }
+
+// resourceUserDeactivate Deactivate a user instead of deleting them, optionally ending their sessions,
+// deleting their tokens and moving them to a different path
+func resourceUserDeactivate(ctx context.Context, d *schema.ResourceData, c *APIClient, id int32) diag.Diagnostics {
+	req := api.PatchedUserRequest{
+		IsActive: new(false),
+		Path:     helpers.GetP[string](d, "deactivate_path"),
+	}
+	_, hr, err := c.client.CoreAPI.CoreUsersPartialUpdate(ctx, id).PatchedUserRequest(req).Execute()
+	if err != nil {
+		return helpers.HTTPToDiag(d, hr, err)
+	}
+	username := d.Get("username").(string)
+	if d.Get("deactivate_revoke_sessions").(bool) {
+		sessions, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreAuthenticatedSessionsList(ctx).UserUsername(username), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
+		})
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		for _, s := range sessions {
+			hr, err := c.client.CoreAPI.CoreAuthenticatedSessionsDestroy(ctx, s.GetUuid()).Execute()
+			if err != nil && (hr == nil || hr.StatusCode != 404) {
+				return helpers.HTTPToDiag(d, hr, err)
+			}
+		}
+	}
+	if d.Get("deactivate_revoke_tokens").(bool) {
+		tokens, hr, err := helpers.Paginator(ctx, c.client.CoreAPI.CoreTokensList(ctx).UserUsername(username), helpers.PaginatorOptions{
+			PageSize: c.pageSize,
+		})
+		if err != nil {
+			return helpers.HTTPToDiag(d, hr, err)
+		}
+		for _, t := range tokens {
+			hr, err := c.client.CoreAPI.CoreTokensDestroy(ctx, t.GetIdentifier()).Execute()
+			if err != nil && (hr == nil || hr.StatusCode != 404) {
+				return helpers.HTTPToDiag(d, hr, err)
+			}
+		}
+	}
+	return diag.Diagnostics{}
+}
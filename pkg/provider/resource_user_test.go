@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceUser(t *testing.T) {
@@ -73,6 +83,122 @@ func TestAccResourceUserAttributes(t *testing.T) {
 	})
 }
 
+func TestAccResourceUserAdoptExisting(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserAdoptExisting(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.existing", "groups.#", "1"),
+				),
+			},
+			{
+				// Adopting the user keeps the groups it's already a member of
+				Config: testAccResourceUserAdoptExisting(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("authentik_user.adopted", "id", "authentik_user.existing", "id"),
+					resource.TestCheckResourceAttr("authentik_user.adopted", "groups.#", "1"),
+					resource.TestCheckResourceAttrPair("authentik_user.adopted", "groups.0", "authentik_group.group", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceUserPasswordWriteOnly(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var id, changed string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserPasswordWriteOnly(rName, rName+"-first", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("authentik_user.name", "password_wo"),
+					resource.TestCheckResourceAttr("authentik_user.name", "password_version", "1"),
+					resource.TestCheckResourceAttr("authentik_user.name", "password_changed_externally", "false"),
+					testAccResourceUserAttr("authentik_user.name", "id", &id),
+					testAccResourceUserAttr("authentik_user.name", "password_change_date", &changed),
+				),
+			},
+			{
+				// Changing the version sets the password again
+				Config: testAccResourceUserPasswordWriteOnly(rName, rName+"-second", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.name", "password_changed_externally", "false"),
+					resource.TestCheckResourceAttrWith("authentik_user.name", "password_change_date", func(value string) error {
+						if value == changed {
+							return fmt.Errorf("expected the password to be changed, last changed at %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// Changing the password outside of Terraform is detected as drift
+				PreConfig:          func() { testAccResourceUserSetPassword(t, id, rName+"-external") },
+				Config:             testAccResourceUserPasswordWriteOnly(rName, rName+"-second", 2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccResourceUserPasswordWriteOnly(rName, rName+"-second", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.name", "password_changed_externally", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceUserDeactivate(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var id string
+	t.Cleanup(func() {
+		if id != "" {
+			testAccAPIRequest(t, http.MethodDelete, fmt.Sprintf("core/users/%s/", id), nil, nil)
+		}
+	})
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserDeactivate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("authentik_user.name", "is_active", "true"),
+					testAccResourceUserAttr("authentik_user.name", "id", &id),
+				),
+			},
+			{
+				// Destroying the user only deactivates them
+				PreConfig: func() {
+					pk, _ := strconv.Atoi(id)
+					testAccAPIRequest(t, http.MethodPost, "core/tokens/", map[string]any{
+						"identifier": rName,
+						"user":       pk,
+					}, nil)
+				},
+				Config: fmt.Sprintf(`
+data "authentik_user" "name" {
+  username = "%[1]s"
+}
+`, rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.authentik_user.name", "is_active", "false"),
+					resource.TestCheckResourceAttr("data.authentik_user.name", "path", rName+"/deactivated"),
+					testAccResourceUserRevoked(t, rName, "core/tokens/"),
+					testAccResourceUserRevoked(t, rName, "core/authenticated_sessions/"),
+				),
+			},
+		},
+	})
+}
+
 func testAccResourceUser(name string) string {
 	return fmt.Sprintf(`
 resource "authentik_user" "name" {
@@ -127,3 +253,119 @@ resource "authentik_user" "name" {
 }
 `, name, attributes)
 }
+
+func testAccResourceUserAdoptExisting(name string, adopt bool) string {
+	config := fmt.Sprintf(`
+resource "authentik_group" "group" {
+  name = "%[1]s"
+}
+resource "authentik_user" "existing" {
+  username = "%[1]s"
+  groups = [authentik_group.group.id]
+}
+`, name)
+	if adopt {
+		config += fmt.Sprintf(`
+resource "authentik_user" "adopted" {
+  username = "%[1]s"
+  adopt_existing = true
+  depends_on = [authentik_user.existing]
+}
+`, name)
+	}
+	return config
+}
+
+func testAccResourceUserPasswordWriteOnly(name string, password string, version int) string {
+	return fmt.Sprintf(`
+resource "authentik_user" "name" {
+  username = "%[1]s"
+  name = "%[1]s"
+  password_wo = "%[2]s"
+  password_version = %[3]d
+}
+`, name, password, version)
+}
+
+func testAccResourceUserDeactivate(name string) string {
+	return fmt.Sprintf(`
+resource "authentik_user" "name" {
+  username = "%[1]s"
+  path = "%[1]s"
+  on_destroy = "deactivate"
+  deactivate_path = "%[1]s/deactivated"
+}
+`, name)
+}
+
+// testAccResourceUserRevoked Check that a user has no objects left in a list, such as tokens or sessions
+func testAccResourceUserRevoked(t *testing.T, username string, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var res struct {
+			Results []any `json:"results"`
+		}
+		testAccAPIRequest(t, http.MethodGet, path+"?user__username="+url.QueryEscape(username), nil, &res)
+		if len(res.Results) > 0 {
+			return fmt.Errorf("expected no %s of user %s, got %d", path, username, len(res.Results))
+		}
+		return nil
+	}
+}
+
+// testAccResourceUserAttr Store an attribute of a resource for later steps
+func testAccResourceUserAttr(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccResourceUserSetPassword Change the password of a user outside of Terraform
+func testAccResourceUserSetPassword(t *testing.T, id string, password string) {
+	testAccAPIRequest(t, http.MethodPost, fmt.Sprintf("core/users/%s/set_password/", id), map[string]any{
+		"password": password,
+	}, nil)
+}
+
+// testAccAPIRequest Send a request to authentik outside of Terraform and decode the response into out
+func testAccAPIRequest(t *testing.T, method string, path string, body any, out any) {
+	path, query, _ := strings.Cut(path, "?")
+	u, err := url.JoinPath(os.Getenv("AUTHENTIK_URL"), "api/v3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "" {
+		u += "?" + query
+	}
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, u, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+os.Getenv("AUTHENTIK_TOKEN"))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode >= 300 {
+		t.Fatalf("%s %s failed with status %d", method, path, res.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMaxWait = 30
+
+	retryBaseWait = 500 * time.Millisecond
+)
+
+type createRetryKey struct{}
+
+// createRetryState Whether an object was created with the requests of a context
+type createRetryState struct {
+	created atomic.Bool
+}
+
+// withCreateRetry Get a context in which reads returning 404 are retried once an object was created,
+// as the new object might not be visible to all authentik workers yet. Otherwise the read following
+// the create would remove the object from the state.
+func withCreateRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, createRetryKey{}, &createRetryState{})
+}
+
+func createRetryFromContext(ctx context.Context) *createRetryState {
+	state, _ := ctx.Value(createRetryKey{}).(*createRetryState)
+	return state
+}
+
+type retryTransport struct {
+	inner      http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// NewRetryTransport Get a HTTP Transport that retries requests which failed due to authentik
+// being temporarily unavailable (for example during a rolling upgrade) or rate-limiting requests
+func NewRetryTransport(inner http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{inner, maxRetries, maxWait}
+}
+
+func (rt *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	attempt := 0
+	for {
+		req, err := rt.requestForAttempt(r, attempt)
+		if err != nil {
+			return nil, err
+		}
+		res, err := rt.inner.RoundTrip(req)
+		if state := createRetryFromContext(r.Context()); state != nil && err == nil && r.Method == http.MethodPost && res.StatusCode < 300 {
+			state.created.Store(true)
+		}
+		if attempt >= rt.maxRetries || !rt.shouldRetry(r, res, err) {
+			return res, err
+		}
+		wait := rt.backoff(attempt, res)
+		if res != nil {
+			log.Printf("[DEBUG] authentik: retrying '%s %s' after status %d in %s", r.Method, r.URL.Path, res.StatusCode, wait)
+			// Drain the body so the connection can be re-used
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		} else {
+			log.Printf("[DEBUG] authentik: retrying '%s %s' after error '%s' in %s", r.Method, r.URL.Path, err.Error(), wait)
+		}
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-time.After(wait):
+		}
+		attempt += 1
+	}
+}
+
+// requestForAttempt Get the request to send for the current attempt, with a fresh body for any retry
+func (rt *retryTransport) requestForAttempt(r *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req := r.Clone(r.Context())
+	req.Body = body
+	return req, nil
+}
+
+func (rt *retryTransport) shouldRetry(r *http.Request, res *http.Response, err error) bool {
+	if r.Context().Err() != nil {
+		return false
+	}
+	// The body of the request can't be re-sent
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(r.Method)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rate-limited requests have not been processed, so they are safe to retry
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(r.Method)
+	case http.StatusNotFound:
+		// Objects might not be visible right after they were created
+		state := createRetryFromContext(r.Context())
+		return r.Method == http.MethodGet && state != nil && state.created.Load()
+	}
+	return false
+}
+
+// backoff Calculate how long to wait before the next attempt, using the `Retry-After` header if set
+// and exponential backoff with jitter otherwise. The result never exceeds the configured maximum.
+func (rt *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, rt.maxWait)
+		}
+	}
+	wait := rt.maxWait
+	// Avoid overflowing the shift for very high retry counts
+	if attempt < 16 {
+		wait = min(retryBaseWait<<attempt, rt.maxWait)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Equal jitter, wait at least half of the calculated time
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
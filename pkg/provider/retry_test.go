@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryServer(t *testing.T, failures int32, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, calls
+}
+
+func TestRetryTransport_RetriesServiceUnavailable(t *testing.T) {
+	srv, calls := testRetryServer(t, 2, http.StatusServiceUnavailable, nil)
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 3, 10*time.Millisecond),
+	}
+	res, err := client.Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryTransport_GivesUp(t *testing.T) {
+	srv, calls := testRetryServer(t, 10, http.StatusBadGateway, nil)
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 2, 10*time.Millisecond),
+	}
+	res, err := client.Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryTransport_NonIdempotent(t *testing.T) {
+	srv, calls := testRetryServer(t, 1, http.StatusServiceUnavailable, nil)
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 3, 10*time.Millisecond),
+	}
+	res, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryTransport_TooManyRequests(t *testing.T) {
+	srv, calls := testRetryServer(t, 1, http.StatusTooManyRequests, http.Header{
+		"Retry-After": []string{"0"},
+	})
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 3, 10*time.Millisecond),
+	}
+	res, err := client.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryTransport_NotFoundAfterCreate(t *testing.T) {
+	reads := &atomic.Int32{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if reads.Add(1) <= 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	client := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, 3, 10*time.Millisecond),
+	}
+	get := func(ctx context.Context) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		assert.NoError(t, err)
+		res, err := client.Do(req)
+		assert.NoError(t, err)
+		return res
+	}
+
+	// Not retried without a create, or before the object was created
+	assert.Equal(t, http.StatusNotFound, get(t.Context()).StatusCode)
+	ctx := withCreateRetry(t.Context())
+	assert.Equal(t, http.StatusNotFound, get(ctx).StatusCode)
+	assert.Equal(t, int32(2), reads.Load())
+
+	reads.Store(0)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL, strings.NewReader("{}"))
+	assert.NoError(t, err)
+	_, err = client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, get(ctx).StatusCode)
+	assert.Equal(t, int32(3), reads.Load())
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	rt := NewRetryTransport(http.DefaultTransport, 3, 5*time.Second)
+	assert.Equal(t, 2*time.Second, rt.backoff(0, &http.Response{
+		Header: http.Header{"Retry-After": []string{"2"}},
+	}))
+	// Retry-After is capped to the maximum wait
+	assert.Equal(t, 5*time.Second, rt.backoff(0, &http.Response{
+		Header: http.Header{"Retry-After": []string{"120"}},
+	}))
+	for attempt := range 20 {
+		wait := rt.backoff(attempt, nil)
+		assert.LessOrEqual(t, wait, 5*time.Second)
+		assert.GreaterOrEqual(t, wait, min(retryBaseWait<<min(attempt, 15), 5*time.Second)/2)
+	}
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestTracingTransport_KeepsRequestContext(t *testing.T) {
+	var inner context.Context
+	tt := NewTracingTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		inner = r.Context()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	ctx, cancel := context.WithCancel(withCreateRetry(t.Context()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	assert.NoError(t, err)
+	_, err = tt.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.NotNil(t, createRetryFromContext(inner))
+	cancel()
+	assert.Error(t, inner.Err())
+}
@@ -15,7 +15,7 @@ func tr(resource func() *schema.Resource) *schema.Resource {
 		span := sentry.StartSpan(ctx, "terraform.resource.create", sentry.WithTransactionName("terraform.resource"))
 		span.Description = "Resource create"
 		defer span.Finish()
-		return so.CreateContext(ctx, rd, m)
+		return so.CreateContext(withCreateRetry(ctx), rd, m)
 	}
 	sc.ReadContext = func(ctx context.Context, rd *schema.ResourceData, m any) diag.Diagnostics {
 		span := sentry.StartSpan(ctx, "terraform.resource.read", sentry.WithTransactionName("terraform.resource"))
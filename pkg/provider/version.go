@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"slices"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "goauthentik.io/api/v3"
+)
+
+// fetchServerVersion Get the version of the authentik server. Returns nil if the version
+// can't be determined, for example when the token isn't allowed to access the admin API.
+func fetchServerVersion(ctx context.Context, client *api.APIClient) *goversion.Version {
+	res, _, err := client.AdminAPI.AdminVersionRetrieve(ctx).Execute()
+	if err != nil {
+		log.Printf("[DEBUG] authentik: failed to get server version: %s", err.Error())
+		return nil
+	}
+	v, err := goversion.NewVersion(res.VersionCurrent)
+	if err != nil {
+		log.Printf("[DEBUG] authentik: failed to parse server version '%s': %s", res.VersionCurrent, err.Error())
+		return nil
+	}
+	return v
+}
+
+// requireVersion Returns an error if the authentik server is older than `minimum`.
+// Pre-releases of a version are treated as that version, and when the server version is
+// unknown the check is skipped.
+func (c *APIClient) requireVersion(feature string, minimum string) diag.Diagnostics {
+	if c.serverVersion == nil {
+		return nil
+	}
+	if !c.serverVersion.Core().LessThan(goversion.Must(goversion.NewVersion(minimum))) {
+		return nil
+	}
+	return diag.Errorf("%s requires authentik >= %s, but the server is running %s", feature, minimum, c.serverVersion.Original())
+}
+
+// versionCheckData Configuration of a resource, either while planning or applying a change
+type versionCheckData interface {
+	GetOk(key string) (any, bool)
+}
+
+// requireAttributeVersions Returns an error for any attribute set in the configuration
+// which requires a newer authentik version than the server is running
+func (c *APIClient) requireAttributeVersions(d versionCheckData, attributes map[string]string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	for _, attr := range slices.Sorted(maps.Keys(attributes)) {
+		if _, ok := d.GetOk(attr); !ok {
+			continue
+		}
+		diags = append(diags, c.requireVersion("`"+attr+"`", attributes[attr])...)
+	}
+	return diags
+}
+
+// withVersionCheck Run `check` when planning, creating and updating a resource
+func withVersionCheck(res *schema.Resource, check func(d versionCheckData, c *APIClient) diag.Diagnostics) {
+	customizeDiff := res.CustomizeDiff
+	res.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m any) error {
+		// The provider might not be configured yet, in which case the check runs when applying
+		if c, ok := m.(*APIClient); ok {
+			if diags := check(d, c); diags.HasError() {
+				return errors.New(diags[0].Summary)
+			}
+		}
+		if customizeDiff != nil {
+			return customizeDiff(ctx, d, m)
+		}
+		return nil
+	}
+	create := res.CreateContext
+	res.CreateContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+		if diags := check(d, m.(*APIClient)); diags.HasError() {
+			return diags
+		}
+		return create(ctx, d, m)
+	}
+	if update := res.UpdateContext; update != nil {
+		res.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+			if diags := check(d, m.(*APIClient)); diags.HasError() {
+				return diags
+			}
+			return update(ctx, d, m)
+		}
+	}
+}
+
+// minVersion Mark a resource as requiring authentik `minimum` or newer, failing with a clear
+// error when planning, and before any request is made when creating or updating it on an older server
+func minVersion(resource func() *schema.Resource, minimum string) func() *schema.Resource {
+	// Fail early if the version is invalid
+	goversion.Must(goversion.NewVersion(minimum))
+	return func() *schema.Resource {
+		res := resource()
+		withVersionCheck(res, func(d versionCheckData, c *APIClient) diag.Diagnostics {
+			return c.requireVersion("This resource", minimum)
+		})
+		return res
+	}
+}
+
+// attributeVersions Mark attributes of a resource as requiring a newer authentik version, by attribute name.
+// Setting them fails when planning, creating or updating the resource on an older server.
+func attributeVersions(resource func() *schema.Resource, attributes map[string]string) func() *schema.Resource {
+	for _, minimum := range attributes {
+		goversion.Must(goversion.NewVersion(minimum))
+	}
+	return func() *schema.Resource {
+		res := resource()
+		withVersionCheck(res, func(d versionCheckData, c *APIClient) diag.Diagnostics {
+			return c.requireAttributeVersions(d, attributes)
+		})
+		return res
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireVersion(t *testing.T) {
+	c := &APIClient{}
+	// Unknown versions are not checked
+	assert.False(t, c.requireVersion("feature", "2025.10").HasError())
+
+	c.serverVersion = goversion.Must(goversion.NewVersion("2025.8.4"))
+	diags := c.requireVersion("feature", "2025.10")
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "feature requires authentik >= 2025.10, but the server is running 2025.8.4", diags[0].Summary)
+	assert.False(t, c.requireVersion("feature", "2025.8").HasError())
+
+	// Release candidates count as the release
+	c.serverVersion = goversion.Must(goversion.NewVersion("2025.10.0-rc1"))
+	assert.False(t, c.requireVersion("feature", "2025.10").HasError())
+}
+
+func TestMinVersion(t *testing.T) {
+	created, updated := false, false
+	res := minVersion(func() *schema.Resource {
+		return &schema.Resource{
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+				created = true
+				return nil
+			},
+			UpdateContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+				updated = true
+				return nil
+			},
+		}
+	}, "2025.10")()
+	assert.NotNil(t, res.CustomizeDiff)
+
+	c := &APIClient{serverVersion: goversion.Must(goversion.NewVersion("2025.8.0"))}
+	diags := res.CreateContext(t.Context(), nil, c)
+	assert.True(t, diags.HasError())
+	assert.False(t, created)
+	diags = res.UpdateContext(t.Context(), nil, c)
+	assert.True(t, diags.HasError())
+	assert.False(t, updated)
+
+	c.serverVersion = goversion.Must(goversion.NewVersion("2025.10.1"))
+	diags = res.CreateContext(t.Context(), nil, c)
+	assert.False(t, diags.HasError())
+	assert.True(t, created)
+}
+
+func TestAttributeVersions(t *testing.T) {
+	res := attributeVersions(func() *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":       {Type: schema.TypeString, Optional: true},
+				"logout_uri": {Type: schema.TypeString, Optional: true},
+			},
+			CreateContext: func(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+				return nil
+			},
+		}
+	}, map[string]string{"logout_uri": "2025.10"})()
+	c := &APIClient{serverVersion: goversion.Must(goversion.NewVersion("2025.8.0"))}
+
+	d := res.TestResourceData()
+	assert.NoError(t, d.Set("name", "test"))
+	assert.False(t, res.CreateContext(t.Context(), d, c).HasError())
+
+	assert.NoError(t, d.Set("logout_uri", "https://example.com"))
+	diags := res.CreateContext(t.Context(), d, c)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "`logout_uri` requires authentik >= 2025.10, but the server is running 2025.8.0", diags[0].Summary)
+}